@@ -45,7 +45,30 @@ _builtinplugins = \
 	ReplicaCountTransformer.go \
 	SecretGenerator.go \
 	ValueAddTransformer.go \
-	HelmChartInflationGenerator.go
+	HelmChartInflationGenerator.go \
+	FsGroupTransformer.go \
+	CronJobMigrationTransformer.go \
+	ImagePullSecretsTransformer.go \
+	VersionLabelTransformer.go \
+	RenameReferencesTransformer.go \
+	FieldToMetadataTransformer.go \
+	EnvToFileTransformer.go \
+	ReadOnlyRootFilesystemTransformer.go \
+	ContainerPortNamesTransformer.go \
+	ProbeDefaultsTransformer.go \
+	ImageDigestLockTransformer.go \
+	DefaultDenyNetworkPolicyTransformer.go \
+	HostAliasesTransformer.go \
+	LabelRemovalTransformer.go \
+	MetadataMoveTransformer.go \
+	ChildNameTransformer.go \
+	PreStopHookTransformer.go \
+	DataMoveTransformer.go \
+	ExternalNameTransformer.go \
+	ProbeTimingsTransformer.go \
+	ImagePullPolicyTransformer.go \
+	ServiceAccountRbacTransformer.go \
+	VolumeClaimStorageTransformer.go
 
 # Maintaining this explicit list of generated files, and
 # adding it as a dependency to a few targets, to assure
@@ -75,6 +98,29 @@ $(pGen)/ReplicaCountTransformer.go: $(pSrc)/replicacounttransformer/ReplicaCount
 $(pGen)/SecretGenerator.go: $(pSrc)/secretgenerator/SecretGenerator.go
 $(pGen)/ValueAddTransformer.go: $(pSrc)/valueaddtransformer/ValueAddTransformer.go
 $(pGen)/HelmChartInflationGenerator.go: $(pSrc)/helmchartinflationgenerator/HelmChartInflationGenerator.go
+$(pGen)/FsGroupTransformer.go: $(pSrc)/fsgrouptransformer/FsGroupTransformer.go
+$(pGen)/CronJobMigrationTransformer.go: $(pSrc)/cronjobmigrationtransformer/CronJobMigrationTransformer.go
+$(pGen)/ImagePullSecretsTransformer.go: $(pSrc)/imagepullsecretstransformer/ImagePullSecretsTransformer.go
+$(pGen)/VersionLabelTransformer.go: $(pSrc)/versionlabeltransformer/VersionLabelTransformer.go
+$(pGen)/RenameReferencesTransformer.go: $(pSrc)/renamereferencestransformer/RenameReferencesTransformer.go
+$(pGen)/FieldToMetadataTransformer.go: $(pSrc)/fieldtometadatatransformer/FieldToMetadataTransformer.go
+$(pGen)/EnvToFileTransformer.go: $(pSrc)/envtofiletransformer/EnvToFileTransformer.go
+$(pGen)/ReadOnlyRootFilesystemTransformer.go: $(pSrc)/readonlyrootfilesystemtransformer/ReadOnlyRootFilesystemTransformer.go
+$(pGen)/ContainerPortNamesTransformer.go: $(pSrc)/containerportnamestransformer/ContainerPortNamesTransformer.go
+$(pGen)/ProbeDefaultsTransformer.go: $(pSrc)/probedefaultstransformer/ProbeDefaultsTransformer.go
+$(pGen)/ImageDigestLockTransformer.go: $(pSrc)/imagedigestlocktransformer/ImageDigestLockTransformer.go
+$(pGen)/DefaultDenyNetworkPolicyTransformer.go: $(pSrc)/defaultdenynetworkpolicytransformer/DefaultDenyNetworkPolicyTransformer.go
+$(pGen)/HostAliasesTransformer.go: $(pSrc)/hostaliasestransformer/HostAliasesTransformer.go
+$(pGen)/LabelRemovalTransformer.go: $(pSrc)/labelremovaltransformer/LabelRemovalTransformer.go
+$(pGen)/MetadataMoveTransformer.go: $(pSrc)/metadatamovetransformer/MetadataMoveTransformer.go
+$(pGen)/ChildNameTransformer.go: $(pSrc)/childnametransformer/ChildNameTransformer.go
+$(pGen)/PreStopHookTransformer.go: $(pSrc)/prestophooktransformer/PreStopHookTransformer.go
+$(pGen)/DataMoveTransformer.go: $(pSrc)/datamovetransformer/DataMoveTransformer.go
+$(pGen)/ExternalNameTransformer.go: $(pSrc)/externalnametransformer/ExternalNameTransformer.go
+$(pGen)/ProbeTimingsTransformer.go: $(pSrc)/probetimingstransformer/ProbeTimingsTransformer.go
+$(pGen)/ImagePullPolicyTransformer.go: $(pSrc)/imagepullpolicytransformer/ImagePullPolicyTransformer.go
+$(pGen)/ServiceAccountRbacTransformer.go: $(pSrc)/serviceaccountrbactransformer/ServiceAccountRbacTransformer.go
+$(pGen)/VolumeClaimStorageTransformer.go: $(pSrc)/volumeclaimstoragetransformer/VolumeClaimStorageTransformer.go
 
 # The (verbose but portable) Makefile way to convert to lowercase.
 toLowerCase = $(subst A,a,$(subst B,b,$(subst C,c,$(subst D,d,$(subst E,e,$(subst F,f,$(subst G,g,$(subst H,h,$(subst I,i,$(subst J,j,$(subst K,k,$(subst L,l,$(subst M,m,$(subst N,n,$(subst O,o,$(subst P,p,$(subst Q,q,$(subst R,r,$(subst S,s,$(subst T,t,$(subst U,u,$(subst V,v,$(subst W,w,$(subst X,x,$(subst Y,y,$(subst Z,z,$1))))))))))))))))))))))))))
@@ -0,0 +1,109 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package claimstorage
+
+import (
+	"math/big"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+//nolint:gochecknoglobals
+var (
+	binarySuffixes  = []string{"Ei", "Pi", "Ti", "Gi", "Mi", "Ki", ""}
+	decimalSuffixes = []string{"E", "P", "T", "G", "M", "k", ""}
+)
+
+// Filter rewrites the storage requested by the volumeClaimTemplates
+// of StatefulSets.
+type Filter struct {
+	// Sizes maps the names of claim templates to the storage they
+	// request, e.g. data: 20Gi.
+	Sizes map[string]string `json:"sizes,omitempty" yaml:"sizes,omitempty"`
+
+	// Multiplier, e.g. "2" or "1.5", scales the storage of the
+	// claim templates that aren't in Sizes.
+	Multiplier string `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if node.GetKind() != "StatefulSet" {
+		return node, nil
+	}
+	templates, err := node.Pipe(yaml.Lookup("spec", "volumeClaimTemplates"))
+	if err != nil || templates == nil {
+		return node, errors.Wrap(err)
+	}
+	err = templates.VisitElements(func(claim *yaml.RNode) error {
+		storage, err := claim.Pipe(
+			yaml.Lookup("spec", "resources", "requests", "storage"))
+		if err != nil || storage == nil {
+			return errors.Wrap(err)
+		}
+		name := claim.GetName()
+		size, err := f.newSize(name, yaml.GetValue(storage))
+		if err != nil {
+			return errors.WrapPrefixf(err,
+				"claim template %q of StatefulSet %s", name, node.GetName())
+		}
+		storage.YNode().Value = size
+		return nil
+	})
+	return node, err
+}
+
+// newSize returns the storage the named claim template should
+// request instead of the given size.
+func (f Filter) newSize(name, size string) (string, error) {
+	if s, ok := f.Sizes[name]; ok {
+		if _, err := parseStorage(s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	if f.Multiplier == "" {
+		return size, nil
+	}
+	multiplier, ok := new(big.Rat).SetString(f.Multiplier)
+	if !ok || multiplier.Sign() <= 0 {
+		return "", errors.Errorf("invalid multiplier %q", f.Multiplier)
+	}
+	q, err := parseStorage(size)
+	if err != nil {
+		return "", err
+	}
+	q.Mul(q, multiplier)
+	if !q.IsInt() {
+		return "", errors.Errorf(
+			"%s times %s is not a whole number of bytes", size, f.Multiplier)
+	}
+	suffixes := decimalSuffixes
+	if strings.HasSuffix(size, "i") {
+		suffixes = binarySuffixes
+	}
+	return filtersutil.FormatQuantity(q, suffixes), nil
+}
+
+// parseStorage parses a storage quantity, which must be a
+// positive whole number of bytes.
+func parseStorage(s string) (*big.Rat, error) {
+	q, err := filtersutil.ParseQuantity(s)
+	if err != nil {
+		return nil, err
+	}
+	if q.Sign() <= 0 || !q.IsInt() {
+		return nil, errors.Errorf("invalid storage size %q", s)
+	}
+	return q, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package claimstorage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const statefulSet = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      resources:
+        requests:
+          storage: 10Gi
+  - metadata:
+      name: logs
+    spec:
+      resources:
+        requests:
+          storage: 500M
+`
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		filter   Filter
+		expected string
+	}{
+		"multiplier": {
+			filter: Filter{Multiplier: "2"},
+			expected: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      resources:
+        requests:
+          storage: 20Gi
+  - metadata:
+      name: logs
+    spec:
+      resources:
+        requests:
+          storage: 1G
+`,
+		},
+		"sizes and fractional multiplier": {
+			filter: Filter{
+				Sizes:      map[string]string{"logs": "2Gi"},
+				Multiplier: "1.5",
+			},
+			expected: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      resources:
+        requests:
+          storage: 15Gi
+  - metadata:
+      name: logs
+    spec:
+      resources:
+        requests:
+          storage: 2Gi
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, statefulSet, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestFilterInvalidResult(t *testing.T) {
+	testCases := map[string]struct {
+		filter   Filter
+		expected string
+	}{
+		"invalid size": {
+			filter:   Filter{Sizes: map[string]string{"data": "lots"}},
+			expected: `claim template "data" of StatefulSet db: invalid quantity "lots"`,
+		},
+		"not whole bytes": {
+			filter:   Filter{Multiplier: "1.0000001"},
+			expected: `claim template "data" of StatefulSet db: 10Gi times 1.0000001 is not a whole number of bytes`,
+		},
+		"zero multiplier": {
+			filter:   Filter{Multiplier: "0"},
+			expected: `claim template "data" of StatefulSet db: invalid multiplier "0"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := tc.filter.Filter(
+				[]*yaml.RNode{yaml.MustParse(statefulSet)})
+			require.Error(t, err)
+			assert.Equal(t, tc.expected, err.Error())
+		})
+	}
+}
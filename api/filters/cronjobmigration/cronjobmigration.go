@@ -0,0 +1,77 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package cronjobmigration
+
+import (
+	"log"
+
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	cronJob    = "CronJob"
+	fromAPI    = "batch/v1beta1"
+	toAPI      = "batch/v1"
+	jobTmplFld = "jobTemplate"
+)
+
+// knownSpecFields are the fields of the batch/v1 CronJobSpec.
+var knownSpecFields = map[string]bool{ //nolint:gochecknoglobals
+	"concurrencyPolicy":          true,
+	"failedJobsHistoryLimit":     true,
+	jobTmplFld:                   true,
+	"schedule":                   true,
+	"startingDeadlineSeconds":    true,
+	"successfulJobsHistoryLimit": true,
+	"suspend":                    true,
+	"timeZone":                   true,
+}
+
+// Filter moves CronJobs from batch/v1beta1 to batch/v1.
+//
+// The two versions share the same schema, so the apiVersion is
+// bumped in place after checking that the object carries the fields
+// batch/v1 requires. Fields of the spec that batch/v1 doesn't know
+// about are kept, and a warning is logged for each of them.
+type Filter struct{}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if node.GetKind() != cronJob || node.GetApiVersion() != fromAPI {
+		return node, nil
+	}
+	name := node.GetName()
+	spec := node.Field("spec")
+	if spec.IsNilOrEmpty() {
+		return nil, errors.Errorf("CronJob %q has no spec", name)
+	}
+	if err := spec.Value.VisitFields(func(n *yaml.MapNode) error {
+		if field := n.Key.YNode().Value; !knownSpecFields[field] {
+			log.Printf(
+				"warning: CronJob %q has field spec.%s, unknown to %s",
+				name, field, toAPI)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if spec.Value.Field("schedule").IsNilOrEmpty() {
+		return nil, errors.Errorf("CronJob %q has no spec.schedule", name)
+	}
+	jobTemplate := spec.Value.Field(jobTmplFld)
+	if jobTemplate.IsNilOrEmpty() ||
+		jobTemplate.Value.YNode().Kind != yaml.MappingNode {
+		return nil, errors.Errorf(
+			"CronJob %q has no valid spec.%s", name, jobTmplFld)
+	}
+	node.SetApiVersion(toAPI)
+	return node, nil
+}
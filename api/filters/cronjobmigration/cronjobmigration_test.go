@@ -0,0 +1,178 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package cronjobmigration
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"beta cronjob": {
+			input: `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+          - name: backup
+            image: backup:1.0
+`,
+			expected: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+          - name: backup
+            image: backup:1.0
+`,
+		},
+		"v1 cronjob unchanged": {
+			input: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  jobTemplate: {}
+`,
+			expected: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  jobTemplate: {}
+`,
+		},
+		"other kinds ignored": {
+			input: `
+apiVersion: batch/v1beta1
+kind: Job
+metadata:
+  name: job
+`,
+			expected: `
+apiVersion: batch/v1beta1
+kind: Job
+metadata:
+  name: job
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, Filter{}))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestFilterInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"no spec": {
+			input: `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+`,
+			expected: `CronJob "backup" has no spec`,
+		},
+		"no schedule": {
+			input: `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec: {}
+`,
+			expected: `CronJob "backup" has no spec.schedule`,
+		},
+		"no job template": {
+			input: `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "@daily"
+`,
+			expected: `CronJob "backup" has no valid spec.jobTemplate`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := Filter{}.Filter([]*yaml.RNode{yaml.MustParse(tc.input)})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestFilterWarnsOnUnknownFields(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	node := yaml.MustParse(`
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "@daily"
+  retries: 3
+  jobTemplate:
+    spec: {}
+`)
+	_, err := Filter{}.Filter([]*yaml.RNode{node})
+	require.NoError(t, err)
+	assert.Equal(t, "batch/v1", node.GetApiVersion())
+	assert.Contains(t, buf.String(),
+		`warning: CronJob "backup" has field spec.retries, unknown to batch/v1`)
+	assert.NotContains(t, buf.String(), "schedule")
+}
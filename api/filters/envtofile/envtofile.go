@@ -0,0 +1,153 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package envtofile
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter removes the literal env vars with the given names from the
+// containers of workloads, and mounts in their place the ConfigMap
+// that is expected to hold them as a file.
+// Env vars set from a valueFrom source are left alone.
+type Filter struct {
+	// EnvVars are the names of the env vars to move.
+	EnvVars []string `json:"envVars,omitempty" yaml:"envVars,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap to mount,
+	// also used as the name of the volume.
+	ConfigMapName string `json:"configMapName,omitempty" yaml:"configMapName,omitempty"`
+
+	// MountPath is the directory the ConfigMap is mounted at.
+	MountPath string `json:"mountPath,omitempty" yaml:"mountPath,omitempty"`
+
+	// Env receives the names and values of the env vars that were
+	// removed. It must not be nil.
+	Env map[string]string `json:"-" yaml:"-"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	containers, err := podSpec.Pipe(yaml.Lookup("containers"))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if containers == nil {
+		return node, nil
+	}
+	moved := false
+	err = containers.VisitElements(func(container *yaml.RNode) error {
+		found, err := f.moveEnv(container)
+		if err != nil || !found {
+			return err
+		}
+		moved = true
+		return f.mount(container)
+	})
+	if err != nil {
+		return nil, errors.WrapPrefixf(err,
+			"moving env of %s %s", node.GetKind(), node.GetName())
+	}
+	if !moved {
+		return node, nil
+	}
+	volumes, err := podSpec.Pipe(yaml.LookupCreate(yaml.SequenceNode, "volumes"))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if volumes.Element("name", f.ConfigMapName) != nil {
+		return node, nil
+	}
+	volume := yaml.NewMapRNode(nil)
+	if err = volume.PipeE(
+		yaml.SetField("name", yaml.NewStringRNode(f.ConfigMapName))); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if err = volume.PipeE(
+		yaml.LookupCreate(yaml.MappingNode, "configMap"),
+		yaml.SetField("name", yaml.NewStringRNode(f.ConfigMapName))); err != nil {
+		return nil, errors.Wrap(err)
+	}
+	return node, errors.Wrap(volumes.PipeE(yaml.Append(volume.YNode())))
+}
+
+// moveEnv removes the env vars to move from the container,
+// recording their values. Returns true if any was found.
+func (f Filter) moveEnv(container *yaml.RNode) (bool, error) {
+	env, err := container.Pipe(yaml.Lookup("env"))
+	if err != nil || env == nil {
+		return false, errors.Wrap(err)
+	}
+	wanted := make(map[string]bool, len(f.EnvVars))
+	for _, name := range f.EnvVars {
+		wanted[name] = true
+	}
+	var kept []*yaml.Node
+	found := false
+	for _, e := range env.Content() {
+		rn := yaml.NewRNode(e)
+		name := fieldValue(rn, "name")
+		if !wanted[name] || rn.Field("value") == nil || rn.Field("valueFrom") != nil {
+			kept = append(kept, e)
+			continue
+		}
+		v := fieldValue(rn, "value")
+		if prev, ok := f.Env[name]; ok && prev != v {
+			return false, errors.Errorf(
+				"env var %s has conflicting values %q and %q", name, prev, v)
+		}
+		f.Env[name] = v
+		found = true
+	}
+	if !found {
+		return false, nil
+	}
+	if len(kept) == 0 {
+		return true, errors.Wrap(container.PipeE(yaml.Clear("env")))
+	}
+	env.YNode().Content = kept
+	return true, nil
+}
+
+// mount adds a mount of the ConfigMap to the container.
+func (f Filter) mount(container *yaml.RNode) error {
+	mounts, err := container.Pipe(
+		yaml.LookupCreate(yaml.SequenceNode, "volumeMounts"))
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	if mounts.Element("name", f.ConfigMapName) != nil {
+		return nil
+	}
+	mount := yaml.NewMapRNode(nil)
+	if err = mount.PipeE(
+		yaml.SetField("name", yaml.NewStringRNode(f.ConfigMapName))); err != nil {
+		return errors.Wrap(err)
+	}
+	if err = mount.PipeE(
+		yaml.SetField("mountPath", yaml.NewStringRNode(f.MountPath))); err != nil {
+		return errors.Wrap(err)
+	}
+	return errors.Wrap(mounts.PipeE(yaml.Append(mount.YNode())))
+}
+
+func fieldValue(rn *yaml.RNode, field string) string {
+	f := rn.Field(field)
+	if f == nil {
+		return ""
+	}
+	return yaml.GetValue(f.Value)
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package envtofile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestFilter(t *testing.T) {
+	env := make(map[string]string)
+	f := Filter{
+		EnvVars:       []string{"DB_HOST", "DB_PORT"},
+		ConfigMapName: "app-env",
+		MountPath:     "/etc/app",
+		Env:           env,
+	}
+	actual := filtertest_test.RunFilter(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        env:
+        - name: DB_HOST
+          value: db
+        - name: LOG_LEVEL
+          value: info
+        - name: DB_PORT
+          value: "5432"
+      - name: sidecar
+        image: sidecar
+        env:
+        - name: DB_HOST
+          valueFrom:
+            configMapKeyRef:
+              name: other
+              key: host
+`, f)
+	assert.Equal(t, strings.TrimSpace(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        env:
+        - name: LOG_LEVEL
+          value: info
+        volumeMounts:
+        - name: app-env
+          mountPath: /etc/app
+      - name: sidecar
+        image: sidecar
+        env:
+        - name: DB_HOST
+          valueFrom:
+            configMapKeyRef:
+              name: other
+              key: host
+      volumes:
+      - name: app-env
+        configMap:
+          name: app-env
+`), strings.TrimSpace(actual))
+	assert.Equal(t, map[string]string{"DB_HOST": "db", "DB_PORT": "5432"}, env)
+}
+
+func TestFilterNoMatch(t *testing.T) {
+	input := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    env:
+    - name: OTHER
+      value: x
+`
+	env := make(map[string]string)
+	actual := filtertest_test.RunFilter(t, input, Filter{
+		EnvVars:       []string{"DB_HOST"},
+		ConfigMapName: "app-env",
+		MountPath:     "/etc/app",
+		Env:           env,
+	})
+	assert.Equal(t, strings.TrimSpace(input), strings.TrimSpace(actual))
+	assert.Empty(t, env)
+}
+
+func TestFilterConflictingValues(t *testing.T) {
+	_, err := Filter{
+		EnvVars:       []string{"A"},
+		ConfigMapName: "env",
+		MountPath:     "/etc/env",
+		Env:           make(map[string]string),
+	}.Filter([]*yaml.RNode{yaml.MustParse(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: a
+    env:
+    - name: A
+      value: "1"
+  - name: b
+    env:
+    - name: A
+      value: "2"
+`)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `env var A has conflicting values "1" and "2"`)
+}
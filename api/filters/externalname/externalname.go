@@ -0,0 +1,66 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package externalname
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter rewrites the domain of the externalName of ExternalName
+// Services. Other Services, and other kinds, are left alone.
+type Filter struct {
+	// Domains maps a domain to the domain that replaces it, e.g.
+	// svc.staging.example.com to svc.prod.example.com. A domain
+	// matches an externalName equal to it or ending in "." and it.
+	// If several domains match, the longest one is replaced.
+	Domains map[string]string `json:"domains,omitempty" yaml:"domains,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if node.GetKind() != "Service" {
+		return node, nil
+	}
+	serviceType, err := node.Pipe(yaml.Lookup("spec", "type"))
+	if err != nil {
+		return node, errors.Wrap(err)
+	}
+	if serviceType == nil || yaml.GetValue(serviceType) != "ExternalName" {
+		return node, nil
+	}
+	externalName, err := node.Pipe(yaml.Lookup("spec", "externalName"))
+	if err != nil || externalName == nil {
+		return node, errors.Wrap(err)
+	}
+	name := yaml.GetValue(externalName)
+	if rewritten, ok := f.rewrite(name); ok {
+		externalName.YNode().Value = rewritten
+	}
+	return node, nil
+}
+
+// rewrite returns name with its longest matching domain replaced,
+// and whether any domain matched.
+func (f Filter) rewrite(name string) (string, bool) {
+	from := ""
+	for domain := range f.Domains {
+		if len(domain) > len(from) &&
+			(name == domain || strings.HasSuffix(name, "."+domain)) {
+			from = domain
+		}
+	}
+	if from == "" {
+		return name, false
+	}
+	return strings.TrimSuffix(name, from) + f.Domains[from], true
+}
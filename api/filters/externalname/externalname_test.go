@@ -0,0 +1,167 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package externalname
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	domains := map[string]string{
+		"staging.example.com":    "prod.example.com",
+		"db.staging.example.com": "db.prod.example.net",
+		"cache.internal":         "cache.prod.internal",
+	}
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"rewrite domain": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+spec:
+  type: ExternalName
+  externalName: api.staging.example.com
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+spec:
+  type: ExternalName
+  externalName: api.prod.example.com
+`,
+			filter: Filter{Domains: domains},
+		},
+		"longest domain wins": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: db
+spec:
+  type: ExternalName
+  externalName: main.db.staging.example.com
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: db
+spec:
+  type: ExternalName
+  externalName: main.db.prod.example.net
+`,
+			filter: Filter{Domains: domains},
+		},
+		"whole name": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: cache
+spec:
+  type: ExternalName
+  externalName: cache.internal
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: cache
+spec:
+  type: ExternalName
+  externalName: cache.prod.internal
+`,
+			filter: Filter{Domains: domains},
+		},
+		"only whole labels match": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+spec:
+  type: ExternalName
+  externalName: api.notstaging.example.com
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+spec:
+  type: ExternalName
+  externalName: api.notstaging.example.com
+`,
+			filter: Filter{Domains: domains},
+		},
+		"skip ClusterIP Service": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: ClusterIP
+  externalName: web.staging.example.com
+  ports:
+  - port: 80
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: ClusterIP
+  externalName: web.staging.example.com
+  ports:
+  - port: 80
+`,
+			filter: Filter{Domains: domains},
+		},
+		"skip Service without type": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+`,
+			filter: Filter{Domains: domains},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
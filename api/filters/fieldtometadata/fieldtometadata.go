@@ -0,0 +1,71 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fieldtometadata
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/annotations"
+	"sigs.k8s.io/kustomize/api/filters/labels"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml_utils "sigs.k8s.io/kustomize/kyaml/utils"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter copies the value of a scalar field of each object into
+// one of its labels or annotations.
+// Objects that don't have the field are left alone.
+type Filter struct {
+	// FieldPath is the dot-separated path of the field to copy,
+	// e.g. spec.replicas.
+	FieldPath string `json:"fieldPath,omitempty" yaml:"fieldPath,omitempty"`
+
+	// Label is the key of the label to set.
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+
+	// Annotation is the key of the annotation to set.
+	Annotation string `json:"annotation,omitempty" yaml:"annotation,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	field, err := node.Pipe(
+		yaml.Lookup(kyaml_utils.SmarterPathSplitter(f.FieldPath, ".")...))
+	if err != nil {
+		return nil, errors.WrapPrefixf(err, "looking up %s", f.FieldPath)
+	}
+	if field.IsNilOrEmpty() {
+		return node, nil
+	}
+	if field.YNode().Kind != yaml.ScalarNode {
+		return nil, errors.Errorf(
+			"field %s of %s %s is not a scalar",
+			f.FieldPath, node.GetKind(), node.GetName())
+	}
+	value := field.YNode().Value
+	var filters []kio.Filter
+	if f.Label != "" {
+		filters = append(filters, labels.Filter{
+			Labels:  map[string]string{f.Label: value},
+			FsSlice: types.FsSlice{{Path: "metadata/labels", CreateIfNotPresent: true}},
+		})
+	}
+	if f.Annotation != "" {
+		filters = append(filters, annotations.Filter{
+			Annotations: map[string]string{f.Annotation: value},
+			FsSlice:     types.FsSlice{{Path: "metadata/annotations", CreateIfNotPresent: true}},
+		})
+	}
+	for _, filter := range filters {
+		if _, err = filter.Filter([]*yaml.RNode{node}); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fieldtometadata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"replicas to annotation": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  replicas: 3
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+  annotations:
+    example.com/replicas: "3"
+spec:
+  replicas: 3
+`,
+			filter: Filter{
+				FieldPath:  "spec.replicas",
+				Annotation: "example.com/replicas",
+			},
+		},
+		"list item to label": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    image: nginx-1.25
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  labels:
+    image: nginx-1.25
+spec:
+  containers:
+  - name: app
+    image: nginx-1.25
+`,
+			filter: Filter{
+				FieldPath: "spec.containers.[name=app].image",
+				Label:     "image",
+			},
+		},
+		"missing source path": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			filter: Filter{
+				FieldPath:  "spec.replicas",
+				Annotation: "example.com/replicas",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filtersutil
+
+import (
+	"math/big"
+	"strings"
+
+	"sigs.k8s.io/kustomize/kyaml/errors"
+)
+
+// quantitySuffixes maps the suffixes of Kubernetes quantities to
+// their multipliers.
+//
+//nolint:gochecknoglobals
+var quantitySuffixes = map[string]*big.Rat{
+	"n":  big.NewRat(1, 1000000000),
+	"u":  big.NewRat(1, 1000000),
+	"m":  big.NewRat(1, 1000),
+	"":   big.NewRat(1, 1),
+	"k":  big.NewRat(1000, 1),
+	"M":  big.NewRat(1000000, 1),
+	"G":  big.NewRat(1000000000, 1),
+	"T":  new(big.Rat).SetInt64(1000000000000),
+	"P":  new(big.Rat).SetInt64(1000000000000000),
+	"E":  new(big.Rat).SetInt64(1000000000000000000),
+	"Ki": big.NewRat(1<<10, 1),
+	"Mi": big.NewRat(1<<20, 1),
+	"Gi": big.NewRat(1<<30, 1),
+	"Ti": new(big.Rat).SetInt64(1 << 40),
+	"Pi": new(big.Rat).SetInt64(1 << 50),
+	"Ei": new(big.Rat).SetInt64(1 << 60),
+}
+
+// ParseQuantity parses a Kubernetes quantity such as 250m or 1.5Gi.
+func ParseQuantity(s string) (*big.Rat, error) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '+' && r != '-'
+	})
+	number, suffix := s, ""
+	if i >= 0 {
+		number, suffix = s[:i], s[i:]
+	}
+	multiplier, ok := quantitySuffixes[suffix]
+	if !ok || number == "" {
+		return nil, errors.Errorf("invalid quantity %q", s)
+	}
+	q, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return nil, errors.Errorf("invalid quantity %q", s)
+	}
+	return q.Mul(q, multiplier), nil
+}
+
+// FormatQuantity formats q with the first of the given suffixes
+// that represents it exactly, e.g. 640Mi, or in thousandths, e.g.
+// 2500m, if none does.
+func FormatQuantity(q *big.Rat, suffixes []string) string {
+	for _, suffix := range suffixes {
+		n := new(big.Rat).Quo(q, quantitySuffixes[suffix])
+		if n.IsInt() && n.Sign() != 0 {
+			return n.Num().String() + suffix
+		}
+	}
+	return new(big.Rat).Quo(q, quantitySuffixes["m"]).FloatString(0) + "m"
+}
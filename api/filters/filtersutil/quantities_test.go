@@ -0,0 +1,31 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filtersutil_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+)
+
+func TestParseQuantity(t *testing.T) {
+	q, err := filtersutil.ParseQuantity("1.5Gi")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(3<<29, 1), q)
+	q, err = filtersutil.ParseQuantity("250m")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 4), q)
+	_, err = filtersutil.ParseQuantity("10Gb")
+	require.EqualError(t, err, `invalid quantity "10Gb"`)
+}
+
+func TestFormatQuantity(t *testing.T) {
+	suffixes := []string{"Gi", "Mi", ""}
+	assert.Equal(t, "2Gi", filtersutil.FormatQuantity(big.NewRat(2<<30, 1), suffixes))
+	assert.Equal(t, "1536Mi", filtersutil.FormatQuantity(big.NewRat(3<<29, 1), suffixes))
+	assert.Equal(t, "2500m", filtersutil.FormatQuantity(big.NewRat(5, 2), suffixes))
+}
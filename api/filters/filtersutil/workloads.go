@@ -0,0 +1,84 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filtersutil
+
+import (
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// podSpecPaths maps the kinds of the built-in workload APIs
+// to the location of the pod spec in their objects.
+var podSpecPaths = map[string][]string{ //nolint:gochecknoglobals
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"Pod":                   {"spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+}
+
+// PodSpecPath returns the path to the pod spec of the given
+// workload kind, or nil if objects of the kind don't hold a pod spec.
+func PodSpecPath(kind string) []string {
+	path, ok := podSpecPaths[kind]
+	if !ok {
+		return nil
+	}
+	return append([]string{}, path...)
+}
+
+// replicasPaths maps the kinds of the built-in workload APIs that
+// can be scaled to the location of their replica count.
+var replicasPaths = map[string][]string{ //nolint:gochecknoglobals
+	"Deployment":            {"spec", "replicas"},
+	"ReplicaSet":            {"spec", "replicas"},
+	"ReplicationController": {"spec", "replicas"},
+	"StatefulSet":           {"spec", "replicas"},
+}
+
+// ReplicasPath returns the path to the replica count of the given
+// workload kind, or nil if objects of the kind don't have one.
+func ReplicasPath(kind string) []string {
+	path, ok := replicasPaths[kind]
+	if !ok {
+		return nil
+	}
+	return append([]string{}, path...)
+}
+
+// LookupPodSpec returns the pod spec of the given workload.
+// Returns nil if the node's kind doesn't hold a pod spec, or if
+// the workload has no pod spec; filters that add to the pod spec
+// should create it with yaml.LookupCreate and PodSpecPath.
+func LookupPodSpec(node *yaml.RNode) (*yaml.RNode, error) {
+	path := PodSpecPath(node.GetKind())
+	if path == nil {
+		return nil, nil
+	}
+	podSpec, err := node.Pipe(yaml.Lookup(path...))
+	return podSpec, errors.Wrap(err)
+}
+
+// VisitContainers calls fn on each container of the given lists,
+// e.g. "containers" or "initContainers", of the pod spec.
+// Lists that don't exist are skipped.
+func VisitContainers(
+	podSpec *yaml.RNode, fn func(container *yaml.RNode) error, lists ...string) error {
+	for _, list := range lists {
+		containers, err := podSpec.Pipe(yaml.Lookup(list))
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if containers == nil {
+			continue
+		}
+		if err = containers.VisitElements(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
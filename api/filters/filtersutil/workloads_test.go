@@ -0,0 +1,99 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filtersutil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestPodSpecPath(t *testing.T) {
+	assert.Equal(t, []string{"spec"}, filtersutil.PodSpecPath("Pod"))
+	assert.Equal(t,
+		[]string{"spec", "template", "spec"},
+		filtersutil.PodSpecPath("Deployment"))
+	assert.Equal(t,
+		[]string{"spec", "jobTemplate", "spec", "template", "spec"},
+		filtersutil.PodSpecPath("CronJob"))
+	assert.Nil(t, filtersutil.PodSpecPath("ConfigMap"))
+}
+
+func TestReplicasPath(t *testing.T) {
+	assert.Equal(t, []string{"spec", "replicas"}, filtersutil.ReplicasPath("StatefulSet"))
+	assert.Nil(t, filtersutil.ReplicasPath("DaemonSet"))
+	assert.Nil(t, filtersutil.ReplicasPath("Pod"))
+}
+
+func TestLookupPodSpec(t *testing.T) {
+	node := yaml.MustParse(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ds
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+`)
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	require.NoError(t, err)
+	require.NotNil(t, podSpec)
+	require.NoError(t, podSpec.PipeE(
+		yaml.SetField("serviceAccountName", yaml.NewScalarRNode("sa"))))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ds
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+      serviceAccountName: sa
+`, node.MustString())
+
+	// A workload without a pod template is left unchanged.
+	bare := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`
+	node = yaml.MustParse(bare)
+	podSpec, err = filtersutil.LookupPodSpec(node)
+	require.NoError(t, err)
+	assert.Nil(t, podSpec)
+	assert.Equal(t, bare, node.MustString())
+
+	podSpec, err = filtersutil.LookupPodSpec(yaml.MustParse(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+`))
+	require.NoError(t, err)
+	assert.Nil(t, podSpec)
+}
+
+func TestVisitContainers(t *testing.T) {
+	podSpec := yaml.MustParse(`
+initContainers:
+- name: init
+containers:
+- name: a
+- name: b
+`)
+	var names []string
+	visit := func(container *yaml.RNode) error {
+		names = append(names, container.Field("name").Value.YNode().Value)
+		return nil
+	}
+	require.NoError(t, filtersutil.VisitContainers(
+		podSpec, visit, "containers", "initContainers", "ephemeralContainers"))
+	assert.Equal(t, []string{"a", "b", "init"}, names)
+}
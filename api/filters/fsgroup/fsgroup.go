@@ -0,0 +1,80 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fsgroup
+
+import (
+	"strconv"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter sets the fsGroup and supplementalGroups fields of the pod
+// security context of workloads. Fields that are already set are
+// left alone, unless Overwrite is true.
+type Filter struct {
+	// FsGroup is the group to set as the fsGroup, if not nil.
+	FsGroup *int64 `json:"fsGroup,omitempty" yaml:"fsGroup,omitempty"`
+
+	// SupplementalGroups are the groups to set as the
+	// supplementalGroups, if not empty.
+	SupplementalGroups []int64 `json:"supplementalGroups,omitempty" yaml:"supplementalGroups,omitempty"`
+
+	// Overwrite replaces values that are already set.
+	Overwrite bool `json:"overwrite,omitempty" yaml:"overwrite,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if f.FsGroup == nil && len(f.SupplementalGroups) == 0 {
+		return node, nil
+	}
+	path := filtersutil.PodSpecPath(node.GetKind())
+	if path == nil {
+		return node, nil
+	}
+	podSpec, err := node.Pipe(yaml.LookupCreate(yaml.MappingNode, path...))
+	if err != nil {
+		return node, errors.Wrap(err)
+	}
+	sc, err := podSpec.Pipe(yaml.LookupCreate(yaml.MappingNode, "securityContext"))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if f.FsGroup != nil && f.shouldSet(sc, "fsGroup") {
+		if err := sc.PipeE(yaml.SetField("fsGroup", intNode(*f.FsGroup))); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	if len(f.SupplementalGroups) > 0 && f.shouldSet(sc, "supplementalGroups") {
+		groups := yaml.NewRNode(&yaml.Node{Kind: yaml.SequenceNode})
+		for _, g := range f.SupplementalGroups {
+			groups.YNode().Content = append(groups.YNode().Content, intNode(g).YNode())
+		}
+		if err := sc.PipeE(yaml.SetField("supplementalGroups", groups)); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	return node, nil
+}
+
+// shouldSet returns true if the field should be written.
+func (f Filter) shouldSet(sc *yaml.RNode, field string) bool {
+	return f.Overwrite || sc.Field(field).IsNilOrEmpty()
+}
+
+func intNode(i int64) *yaml.RNode {
+	return yaml.NewRNode(&yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: strconv.FormatInt(i, 10),
+		Tag:   yaml.NodeTagInt,
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fsgroup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"bare deployment": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx
+      securityContext:
+        fsGroup: 2000
+        supplementalGroups:
+        - 3000
+        - 4000
+`,
+			filter: Filter{
+				FsGroup:            int64Ptr(2000),
+				SupplementalGroups: []int64{3000, 4000},
+			},
+		},
+		"preserve explicit values": {
+			input: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: sts
+spec:
+  template:
+    spec:
+      securityContext:
+        fsGroup: 1000
+        supplementalGroups:
+        - 1
+`,
+			expected: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: sts
+spec:
+  template:
+    spec:
+      securityContext:
+        fsGroup: 1000
+        supplementalGroups:
+        - 1
+`,
+			filter: Filter{
+				FsGroup:            int64Ptr(2000),
+				SupplementalGroups: []int64{3000},
+			},
+		},
+		"overwrite explicit values": {
+			input: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cj
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          securityContext:
+            fsGroup: 1000
+            runAsUser: 1
+`,
+			expected: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cj
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          securityContext:
+            fsGroup: 2000
+            runAsUser: 1
+`,
+			filter: Filter{
+				FsGroup:   int64Ptr(2000),
+				Overwrite: true,
+			},
+		},
+		"pod": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+  securityContext:
+    fsGroup: 2000
+`,
+			filter: Filter{
+				FsGroup: int64Ptr(2000),
+			},
+		},
+		"ignore non-workloads": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+spec:
+  ports:
+  - port: 80
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+spec:
+  ports:
+  - port: 80
+`,
+			filter: Filter{
+				FsGroup: int64Ptr(2000),
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
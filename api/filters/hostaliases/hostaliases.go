@@ -0,0 +1,85 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostaliases
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// HostAlias maps hostnames to an IP address in the pods' hosts file.
+type HostAlias struct {
+	IP        string   `json:"ip,omitempty" yaml:"ip,omitempty"`
+	Hostnames []string `json:"hostnames,omitempty" yaml:"hostnames,omitempty"`
+}
+
+// Filter adds host aliases to the pod specs of workloads. The
+// hostnames of an alias are merged into the existing alias with
+// the same IP, if there is one.
+type Filter struct {
+	HostAliases []HostAlias `json:"hostAliases,omitempty" yaml:"hostAliases,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	path := filtersutil.PodSpecPath(node.GetKind())
+	if path == nil {
+		return node, nil
+	}
+	podSpec, err := node.Pipe(yaml.LookupCreate(yaml.MappingNode, path...))
+	if err != nil {
+		return node, errors.Wrap(err)
+	}
+	aliases, err := podSpec.Pipe(
+		yaml.LookupCreate(yaml.SequenceNode, "hostAliases"))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	for _, ha := range f.HostAliases {
+		alias := aliases.Element("ip", ha.IP)
+		if alias == nil {
+			alias = yaml.NewMapRNode(nil)
+			err = alias.PipeE(yaml.SetField("ip", yaml.NewStringRNode(ha.IP)))
+			if err != nil {
+				return nil, errors.Wrap(err)
+			}
+			if err = aliases.PipeE(yaml.Append(alias.YNode())); err != nil {
+				return nil, errors.Wrap(err)
+			}
+		}
+		if err = addHostnames(alias, ha.Hostnames); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// addHostnames adds the hostnames the alias doesn't have yet.
+func addHostnames(alias *yaml.RNode, hostnames []string) error {
+	list, err := alias.Pipe(yaml.LookupCreate(yaml.SequenceNode, "hostnames"))
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	existing := make(map[string]bool)
+	for _, n := range list.Content() {
+		existing[n.Value] = true
+	}
+	for _, h := range hostnames {
+		if existing[h] {
+			continue
+		}
+		existing[h] = true
+		if err = list.PipeE(yaml.Append(yaml.NewStringRNode(h).YNode())); err != nil {
+			return errors.Wrap(err)
+		}
+	}
+	return nil
+}
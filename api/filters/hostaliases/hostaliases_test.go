@@ -0,0 +1,119 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostaliases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"add alias": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+      hostAliases:
+      - ip: 10.0.0.5
+        hostnames:
+        - db.legacy
+        - db
+`,
+			filter: Filter{HostAliases: []HostAlias{
+				{IP: "10.0.0.5", Hostnames: []string{"db.legacy", "db", "db"}},
+			}},
+		},
+		"merge by ip": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  hostAliases:
+  - ip: 10.0.0.5
+    hostnames:
+    - db
+  - ip: 10.0.0.6
+    hostnames:
+    - cache
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  hostAliases:
+  - ip: 10.0.0.5
+    hostnames:
+    - db
+    - db.legacy
+  - ip: 10.0.0.6
+    hostnames:
+    - cache
+  - ip: 10.0.0.7
+    hostnames:
+    - queue
+`,
+			filter: Filter{HostAliases: []HostAlias{
+				{IP: "10.0.0.5", Hostnames: []string{"db", "db.legacy"}},
+				{IP: "10.0.0.7", Hostnames: []string{"queue"}},
+			}},
+		},
+		"not a workload": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			filter: Filter{HostAliases: []HostAlias{
+				{IP: "10.0.0.5", Hostnames: []string{"db"}},
+			}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagedigests
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/image"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter pins the images of the containers of workloads to
+// digests, replacing any tag or digest they already have.
+type Filter struct {
+	// Digests maps images to digests, e.g. "nginx:1.25" or
+	// "nginx" to "sha256:...". An image with a tag is looked up
+	// by name and tag first, then by name alone.
+	Digests map[string]string `json:"digests,omitempty" yaml:"digests,omitempty"`
+
+	// Strict makes it an error for an image to be missing
+	// from Digests.
+	Strict bool `json:"strict,omitempty" yaml:"strict,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	return node, filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		field := container.Field("image")
+		if field.IsNilOrEmpty() {
+			return nil
+		}
+		value := yaml.GetValue(field.Value)
+		pinned, ok := f.pin(value)
+		if !ok {
+			if f.Strict {
+				return errors.Errorf(
+					"image %q of %s %s is not in the digest lockfile",
+					value, node.GetKind(), node.GetName())
+			}
+			return nil
+		}
+		field.Value.YNode().Value = pinned
+		return nil
+	}, "containers", "initContainers")
+}
+
+// pin returns the image pinned to its digest, and whether
+// the image has a digest.
+func (f Filter) pin(value string) (string, bool) {
+	name, tag, _ := image.Split(value)
+	digest, ok := "", false
+	if tag != "" {
+		digest, ok = f.Digests[name+":"+tag]
+	}
+	if !ok {
+		digest, ok = f.Digests[name]
+	}
+	if !ok {
+		return "", false
+	}
+	return name + "@" + digest, true
+}
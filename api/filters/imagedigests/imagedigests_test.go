@@ -0,0 +1,107 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagedigests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+)
+
+func TestFilter(t *testing.T) {
+	digests := map[string]string{
+		"nginx:1.25":             "sha256:aaaa",
+		"nginx":                  "sha256:bbbb",
+		"registry.example/app":   "sha256:cccc",
+		"registry.example/init":  "sha256:dddd",
+		"registry.example/other": "sha256:eeee",
+	}
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"pin images": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: registry.example/init@sha256:0000
+      containers:
+      - name: web
+        image: nginx:1.25
+      - name: legacy
+        image: nginx:1.19
+      - name: app
+        image: registry.example/app
+      - name: sidecar
+        image: busybox
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: registry.example/init@sha256:dddd
+      containers:
+      - name: web
+        image: nginx@sha256:aaaa
+      - name: legacy
+        image: nginx@sha256:bbbb
+      - name: app
+        image: registry.example/app@sha256:cccc
+      - name: sidecar
+        image: busybox
+`,
+			filter: Filter{Digests: digests},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestFilterStrict(t *testing.T) {
+	nodes, err := kio.FromBytes([]byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    image: busybox:1.36
+`))
+	require.NoError(t, err)
+	_, err = Filter{
+		Digests: map[string]string{"nginx": "sha256:aaaa"},
+		Strict:  true,
+	}.Filter(nodes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		`image "busybox:1.36" of Pod pod is not in the digest lockfile`)
+}
@@ -0,0 +1,6 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imagepullsecrets contains a kio.Filter implementation of the
+// kustomize ImagePullSecretsTransformer.
+package imagepullsecrets
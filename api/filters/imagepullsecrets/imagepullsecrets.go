@@ -0,0 +1,63 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagepullsecrets
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const imagePullSecretsField = "imagePullSecrets"
+
+// Filter appends entries to the imagePullSecrets of the pod spec of
+// workloads, and optionally of ServiceAccounts. Secrets that are
+// already listed are not added again.
+type Filter struct {
+	// Secrets are the names of the secrets to add.
+	Secrets []string `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+
+	// ServiceAccounts also adds the secrets to ServiceAccount objects.
+	ServiceAccounts bool `json:"serviceAccounts,omitempty" yaml:"serviceAccounts,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if len(f.Secrets) == 0 {
+		return node, nil
+	}
+	target := node
+	if !f.ServiceAccounts || node.GetKind() != "ServiceAccount" {
+		path := filtersutil.PodSpecPath(node.GetKind())
+		if path == nil {
+			return node, nil
+		}
+		podSpec, err := node.Pipe(yaml.LookupCreate(yaml.MappingNode, path...))
+		if err != nil {
+			return node, errors.Wrap(err)
+		}
+		target = podSpec
+	}
+	list, err := target.Pipe(
+		yaml.LookupCreate(yaml.SequenceNode, imagePullSecretsField))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	for _, name := range f.Secrets {
+		if list.Element("name", name) != nil {
+			continue
+		}
+		ref := yaml.NewMapRNode(&map[string]string{"name": name})
+		if err := list.PipeE(yaml.Append(ref.YNode())); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package imagepullsecrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"add to deployment": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: registry.example.com/app
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: registry.example.com/app
+      imagePullSecrets:
+      - name: regcred
+`,
+			filter: Filter{Secrets: []string{"regcred"}},
+		},
+		"no duplicates": {
+			input: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cj
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          imagePullSecrets:
+          - name: regcred
+`,
+			expected: `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cj
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          imagePullSecrets:
+          - name: regcred
+          - name: other
+`,
+			filter: Filter{Secrets: []string{"regcred", "other"}},
+		},
+		"service account": {
+			input: `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+`,
+			expected: `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+imagePullSecrets:
+- name: regcred
+`,
+			filter: Filter{Secrets: []string{"regcred"}, ServiceAccounts: true},
+		},
+		"service account not configured": {
+			input: `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+`,
+			expected: `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sa
+`,
+			filter: Filter{Secrets: []string{"regcred"}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
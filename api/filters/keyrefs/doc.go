@@ -0,0 +1,7 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keyrefs contains a kio.Filter that rewires the
+// configMapKeyRef and secretKeyRef references of containers,
+// used by the kustomize DataMoveTransformer.
+package keyrefs
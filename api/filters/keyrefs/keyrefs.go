@@ -0,0 +1,96 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package keyrefs
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Source is a ConfigMap or Secret that env vars take values from.
+type Source struct {
+	// Kind is ConfigMap or Secret.
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// refField returns the field of an env var's valueFrom that
+// refers to a key of the source.
+func (s Source) refField() (string, error) {
+	switch s.Kind {
+	case "ConfigMap":
+		return "configMapKeyRef", nil
+	case "Secret":
+		return "secretKeyRef", nil
+	default:
+		return "", errors.Errorf("kind must be ConfigMap or Secret, not %q", s.Kind)
+	}
+}
+
+// Filter changes the env vars of workload containers that take the
+// given keys from one ConfigMap or Secret to take them from another.
+type Filter struct {
+	From Source   `json:"from,omitempty" yaml:"from,omitempty"`
+	To   Source   `json:"to,omitempty" yaml:"to,omitempty"`
+	Keys []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	fromField, err := f.From.refField()
+	if err != nil {
+		return nil, err
+	}
+	toField, err := f.To.refField()
+	if err != nil {
+		return nil, err
+	}
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	keys := make(map[string]bool, len(f.Keys))
+	for _, k := range f.Keys {
+		keys[k] = true
+	}
+	return node, filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		env, err := container.Pipe(yaml.Lookup("env"))
+		if err != nil || env == nil {
+			return errors.Wrap(err)
+		}
+		return env.VisitElements(func(envVar *yaml.RNode) error {
+			valueFrom, err := envVar.Pipe(yaml.Lookup("valueFrom"))
+			if err != nil || valueFrom == nil {
+				return errors.Wrap(err)
+			}
+			field := valueFrom.Field(fromField)
+			if field.IsNilOrEmpty() {
+				return nil
+			}
+			if stringField(field.Value, "name") != f.From.Name ||
+				!keys[stringField(field.Value, "key")] {
+				return nil
+			}
+			// Rename the field in place to keep the field order.
+			field.Key.YNode().Value = toField
+			return errors.Wrap(field.Value.PipeE(
+				yaml.SetField("name", yaml.NewStringRNode(f.To.Name))))
+		})
+	}, "containers", "initContainers")
+}
+
+func stringField(node *yaml.RNode, name string) string {
+	field := node.Field(name)
+	if field.IsNilOrEmpty() {
+		return ""
+	}
+	return yaml.GetValue(field.Value)
+}
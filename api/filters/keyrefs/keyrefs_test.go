@@ -0,0 +1,132 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package keyrefs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"configmap to secret": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        env:
+        - name: PASSWORD
+          valueFrom:
+            configMapKeyRef:
+              name: app-config
+              key: password
+        - name: HOST
+          valueFrom:
+            configMapKeyRef:
+              name: app-config
+              key: host
+        - name: OTHER
+          valueFrom:
+            configMapKeyRef:
+              name: other-config
+              key: password
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        env:
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: app-secret
+              key: password
+        - name: HOST
+          valueFrom:
+            configMapKeyRef:
+              name: app-config
+              key: host
+        - name: OTHER
+          valueFrom:
+            configMapKeyRef:
+              name: other-config
+              key: password
+`,
+			filter: Filter{
+				From: Source{Kind: "ConfigMap", Name: "app-config"},
+				To:   Source{Kind: "Secret", Name: "app-secret"},
+				Keys: []string{"password"},
+			},
+		},
+		"secret to configmap": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  initContainers:
+  - name: init
+    env:
+    - name: HOST
+      valueFrom:
+        secretKeyRef:
+          name: app-secret
+          key: host
+          optional: true
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  initContainers:
+  - name: init
+    env:
+    - name: HOST
+      valueFrom:
+        configMapKeyRef:
+          name: app-config
+          key: host
+          optional: true
+`,
+			filter: Filter{
+				From: Source{Kind: "Secret", Name: "app-secret"},
+				To:   Source{Kind: "ConfigMap", Name: "app-config"},
+				Keys: []string{"host"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
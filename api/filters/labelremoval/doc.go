@@ -0,0 +1,6 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package labelremoval contains a kio.Filter implementation of the
+// kustomize LabelRemovalTransformer.
+package labelremoval
@@ -0,0 +1,113 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package labelremoval
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter removes labels from the metadata of resources and from
+// the pod templates of workloads, leaving selectors alone, as
+// selectors of most workloads are immutable.
+//
+// A label the workload's own selector uses stays in its pod
+// template, as the selector must keep matching the template.
+type Filter struct {
+	Labels []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if err := f.removeFrom(node, nil, yaml.MetadataField); err != nil {
+		return nil, err
+	}
+	path := filtersutil.PodSpecPath(node.GetKind())
+	if len(path) < 2 {
+		// not a workload, or a Pod, whose labels are its metadata labels
+		return node, nil
+	}
+	template := path[:len(path)-1]
+	// Limit the capacity of the spec path, so that appending
+	// to it doesn't overwrite the template path.
+	keep, err := selectorKeys(node, template[:len(template)-1:len(template)-1])
+	if err != nil {
+		return nil, err
+	}
+	return node, f.removeFrom(node, keep, append(template, yaml.MetadataField)...)
+}
+
+// removeFrom removes the labels not in keep from the labels field
+// of the metadata at the given path.
+func (f Filter) removeFrom(node *yaml.RNode, keep map[string]bool, path ...string) error {
+	labels, err := node.Pipe(yaml.Lookup(append(path, yaml.LabelsField)...))
+	if err != nil || labels == nil {
+		return errors.Wrap(err)
+	}
+	for _, label := range f.Labels {
+		if keep[label] {
+			continue
+		}
+		if _, err = labels.Pipe(yaml.Clear(label)); err != nil {
+			return errors.Wrap(err)
+		}
+	}
+	if len(labels.Content()) > 0 {
+		return nil
+	}
+	_, err = node.Pipe(yaml.Lookup(path...), yaml.Clear(yaml.LabelsField))
+	return errors.Wrap(err)
+}
+
+// selectorKeys returns the label keys used by the selector in the
+// spec at the given path.
+func selectorKeys(node *yaml.RNode, specPath []string) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	selector, err := node.Pipe(yaml.Lookup(append(specPath, "selector")...))
+	if err != nil || selector == nil {
+		return keys, errors.Wrap(err)
+	}
+	if selector.YNode().Kind != yaml.MappingNode {
+		return keys, nil
+	}
+	matchLabels := selector.Field("matchLabels")
+	matchExpressions := selector.Field("matchExpressions")
+	if matchLabels == nil && matchExpressions == nil {
+		// e.g. a ReplicationController, whose selector is a plain map
+		return keys, errors.Wrap(addKeys(keys, selector))
+	}
+	if matchLabels != nil {
+		if err = addKeys(keys, matchLabels.Value); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	if matchExpressions != nil {
+		values, err := matchExpressions.Value.ElementValues("key")
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		for _, v := range values {
+			keys[v] = true
+		}
+	}
+	return keys, nil
+}
+
+func addKeys(keys map[string]bool, m *yaml.RNode) error {
+	fields, err := m.Fields()
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		keys[f] = true
+	}
+	return nil
+}
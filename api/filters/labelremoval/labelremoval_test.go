@@ -0,0 +1,135 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package labelremoval
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"deployment": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+  labels:
+    app: web
+    version: v1
+    owner: team-a
+spec:
+  selector:
+    matchLabels:
+      app: web
+      version: v1
+  template:
+    metadata:
+      labels:
+        app: web
+        version: v1
+        owner: team-a
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+  labels:
+    app: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+      version: v1
+  template:
+    metadata:
+      labels:
+        app: web
+        version: v1
+`,
+			filter: Filter{Labels: []string{"version", "owner"}},
+		},
+		"match expressions": {
+			input: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  labels:
+    tier: db
+spec:
+  selector:
+    matchExpressions:
+    - key: tier
+      operator: In
+      values: [db]
+  template:
+    metadata:
+      labels:
+        tier: db
+        canary: "false"
+`,
+			expected: `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  selector:
+    matchExpressions:
+    - key: tier
+      operator: In
+      values: [db]
+  template:
+    metadata:
+      labels:
+        tier: db
+`,
+			filter: Filter{Labels: []string{"tier", "canary"}},
+		},
+		"service": {
+			input: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+  labels:
+    version: v1
+spec:
+  selector:
+    version: v1
+`,
+			expected: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: svc
+spec:
+  selector:
+    version: v1
+`,
+			filter: Filter{Labels: []string{"version"}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadatamove
+
+import (
+	"regexp"
+	"sort"
+
+	"sigs.k8s.io/kustomize/api/filters/annotations"
+	"sigs.k8s.io/kustomize/api/filters/labels"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const maxLabelValueLength = 63
+
+var labelValueRegexp = regexp.MustCompile(
+	`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`)
+
+// Filter moves values between the labels and the annotations of
+// resources. Resources without a key to move are left alone.
+type Filter struct {
+	// AnnotationsToLabels maps the keys of annotations to the keys
+	// of the labels to move their values to.
+	AnnotationsToLabels map[string]string `json:"annotationsToLabels,omitempty" yaml:"annotationsToLabels,omitempty"`
+
+	// LabelsToAnnotations maps the keys of labels to the keys of
+	// the annotations to move their values to.
+	LabelsToAnnotations map[string]string `json:"labelsToAnnotations,omitempty" yaml:"labelsToAnnotations,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	toLabels, err := take(node, yaml.AnnotationsField, f.AnnotationsToLabels)
+	if err != nil {
+		return nil, err
+	}
+	toAnnotations, err := take(node, yaml.LabelsField, f.LabelsToAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range sortedKeys(toLabels) {
+		if err = validateLabelValue(toLabels[k]); err != nil {
+			return nil, errors.WrapPrefixf(err,
+				"cannot move annotation to label %s of %s %s",
+				k, node.GetKind(), node.GetName())
+		}
+	}
+	if len(toLabels) > 0 {
+		_, err = labels.Filter{
+			Labels:  toLabels,
+			FsSlice: types.FsSlice{{Path: "metadata/labels", CreateIfNotPresent: true}},
+		}.Filter([]*yaml.RNode{node})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(toAnnotations) > 0 {
+		_, err = annotations.Filter{
+			Annotations: toAnnotations,
+			FsSlice:     types.FsSlice{{Path: "metadata/annotations", CreateIfNotPresent: true}},
+		}.Filter([]*yaml.RNode{node})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// take removes the keys of the mapping from the given metadata
+// field, returning their values under the keys they map to.
+func take(node *yaml.RNode, field string, mapping map[string]string) (map[string]string, error) {
+	values := make(map[string]string)
+	m, err := node.Pipe(yaml.Lookup(yaml.MetadataField, field))
+	if err != nil || m == nil {
+		return values, errors.Wrap(err)
+	}
+	for _, from := range sortedKeys(mapping) {
+		v, err := m.Pipe(yaml.Get(from))
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		if v == nil {
+			continue
+		}
+		values[mapping[from]] = yaml.GetValue(v)
+		if _, err = m.Pipe(yaml.Clear(from)); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	if len(m.Content()) == 0 {
+		_, err = node.Pipe(yaml.Lookup(yaml.MetadataField), yaml.Clear(field))
+	}
+	return values, errors.Wrap(err)
+}
+
+func validateLabelValue(v string) error {
+	if len(v) > maxLabelValueLength {
+		return errors.Errorf(
+			"label value %q is longer than %d characters", v, maxLabelValueLength)
+	}
+	if !labelValueRegexp.MatchString(v) {
+		return errors.Errorf("invalid label value %q: must consist of "+
+			"alphanumeric characters, '-', '_' or '.', and must start "+
+			"and end with an alphanumeric character", v)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package metadatamove
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"annotation to label": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    example.com/team: payments
+    note: keep me
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    note: keep me
+  labels:
+    team: payments
+`,
+			filter: Filter{
+				AnnotationsToLabels: map[string]string{"example.com/team": "team"},
+			},
+		},
+		"label to annotation": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  labels:
+    app: web
+    build: "1234"
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  labels:
+    app: web
+  annotations:
+    example.com/build: "1234"
+`,
+			filter: Filter{
+				LabelsToAnnotations: map[string]string{"build": "example.com/build"},
+			},
+		},
+		"last annotation": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    example.com/team: payments
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  labels:
+    team: payments
+`,
+			filter: Filter{
+				AnnotationsToLabels: map[string]string{"example.com/team": "team"},
+			},
+		},
+		"missing key": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+			filter: Filter{
+				AnnotationsToLabels: map[string]string{"example.com/team": "team"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestFilterInvalidLabelValue(t *testing.T) {
+	nodes, err := kio.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    description: not a label value
+`))
+	require.NoError(t, err)
+	_, err = Filter{
+		AnnotationsToLabels: map[string]string{"description": "description"},
+	}.Filter(nodes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		`cannot move annotation to label description of ConfigMap cm: `+
+			`invalid label value "not a label value"`)
+}
@@ -6,6 +6,7 @@ package namespace
 import (
 	"sigs.k8s.io/kustomize/api/filters/filtersutil"
 	"sigs.k8s.io/kustomize/api/filters/fsslice"
+	"sigs.k8s.io/kustomize/api/konfig"
 	"sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/errors"
 	"sigs.k8s.io/kustomize/kyaml/kio"
@@ -88,9 +89,20 @@ func (ns Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
 
 // metaNamespaceHack is a hack for implementing the namespace transform
 // for the metadata.namespace field on namespace scoped resources.
+// Cluster-scoped resources don't get the namespace, and lose any
+// stray one they have, unless they have the annotation
+// konfig.ForceNamespaceAnnotation set to "true".
 func (ns Filter) metaNamespaceHack(obj *yaml.RNode, gvk resid.Gvk) error {
-	if gvk.IsClusterScoped() {
-		return nil
+	if !gvk.IsNamespaceable() {
+		force, err := obj.Pipe(yaml.GetAnnotation(konfig.ForceNamespaceAnnotation))
+		if err != nil {
+			return err
+		}
+		if yaml.GetValue(force) != "true" {
+			return obj.PipeE(
+				yaml.Lookup(yaml.MetadataField),
+				yaml.Clear(yaml.NamespaceField))
+		}
 	}
 	f := fsslice.Filter{
 		FsSlice: []types.FieldSpec{
@@ -386,6 +386,88 @@ metadata:
 `,
 		filter: namespace.Filter{Namespace: "01234"},
 	},
+
+	{
+		name: "cluster-scoped",
+		input: `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: team
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: forced
+  annotations:
+    kustomize.config.k8s.io/force-namespace: "true"
+`,
+		expected: `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: foo
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: foo
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: forced
+  annotations:
+    kustomize.config.k8s.io/force-namespace: "true"
+  namespace: foo
+`,
+		filter: namespace.Filter{Namespace: "foo"},
+	},
+
+	{
+		name: "cluster-scoped with a stray namespace",
+		input: `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+  namespace: a
+---
+apiVersion: v1
+kind: PersistentVolume
+metadata:
+  name: data
+  namespace: b
+`,
+		expected: `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+apiVersion: v1
+kind: PersistentVolume
+metadata:
+  name: data
+`,
+		filter: namespace.Filter{Namespace: "foo"},
+	},
 }
 
 type TestCase struct {
@@ -0,0 +1,116 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package portnames
+
+import (
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// maxNameLength is the maximum length of a container port name.
+const maxNameLength = 15
+
+// Filter names the unnamed container ports of workloads after
+// their protocol and number, e.g. tcp-8080, and optionally
+// changes the numbers of named ports.
+type Filter struct {
+	// Numbers maps port names to the container port numbers
+	// they should have.
+	Numbers map[string]int `json:"numbers,omitempty" yaml:"numbers,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	return node, filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		ports, err := container.Pipe(yaml.Lookup("ports"))
+		if err != nil || ports == nil {
+			return errors.Wrap(err)
+		}
+		elements, err := ports.Elements()
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		for _, port := range elements {
+			if err = f.setName(port); err != nil {
+				return err
+			}
+			if err = f.setNumber(port); err != nil {
+				return err
+			}
+		}
+		return checkUnique(elements)
+	}, "containers", "initContainers")
+}
+
+// setName names the port after its protocol and number if it
+// has no name.
+func (f Filter) setName(port *yaml.RNode) error {
+	if !port.Field("name").IsNilOrEmpty() {
+		return nil
+	}
+	number := port.Field("containerPort")
+	if number.IsNilOrEmpty() {
+		return errors.Errorf("unnamed port has no containerPort")
+	}
+	protocol := "TCP"
+	if p := port.Field("protocol"); !p.IsNilOrEmpty() {
+		protocol = yaml.GetValue(p.Value)
+	}
+	name := strings.ToLower(protocol) + "-" + yaml.GetValue(number.Value)
+	if len(name) > maxNameLength {
+		return errors.Errorf(
+			"port name %q derived from protocol and number is longer than %d characters",
+			name, maxNameLength)
+	}
+	return errors.Wrap(port.PipeE(yaml.SetField("name", yaml.NewStringRNode(name))))
+}
+
+// setNumber sets the number of the port if its name is in Numbers.
+func (f Filter) setNumber(port *yaml.RNode) error {
+	name, err := port.GetString("name")
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	number, ok := f.Numbers[name]
+	if !ok {
+		return nil
+	}
+	return errors.Wrap(port.PipeE(yaml.SetField("containerPort", yaml.NewRNode(&yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: strconv.Itoa(number),
+		Tag:   yaml.NodeTagInt,
+	}))))
+}
+
+// checkUnique returns an error if two ports of a container
+// have the same name, e.g. because a derived name clashes with
+// an explicit one.
+func checkUnique(ports []*yaml.RNode) error {
+	seen := make(map[string]bool, len(ports))
+	for _, port := range ports {
+		name, err := port.GetString("name")
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if seen[name] {
+			return errors.Errorf("container has more than one port named %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
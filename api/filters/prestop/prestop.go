@@ -0,0 +1,137 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package prestop
+
+import (
+	"strconv"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Handler is a lifecycle hook handler, with either an exec or an
+// httpGet action.
+type Handler struct {
+	Exec    *ExecAction    `json:"exec,omitempty" yaml:"exec,omitempty"`
+	HTTPGet *HTTPGetAction `json:"httpGet,omitempty" yaml:"httpGet,omitempty"`
+}
+
+// ExecAction runs a command in the container.
+type ExecAction struct {
+	Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+// HTTPGetAction sends an HTTP GET request to the container.
+type HTTPGetAction struct {
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+// Validate returns an error if the handler doesn't have exactly
+// one complete action.
+func (h *Handler) Validate() error {
+	switch {
+	case h.Exec != nil && h.HTTPGet != nil:
+		return errors.Errorf("handler must not have both exec and httpGet")
+	case h.Exec != nil:
+		if len(h.Exec.Command) == 0 {
+			return errors.Errorf("exec handler must have a command")
+		}
+	case h.HTTPGet != nil:
+		if h.HTTPGet.Port <= 0 {
+			return errors.Errorf("httpGet handler must have a port")
+		}
+	default:
+		return errors.Errorf("handler must have exec or httpGet")
+	}
+	return nil
+}
+
+// node returns the handler as a yaml node.
+func (h *Handler) node() (*yaml.RNode, error) {
+	action := yaml.NewMapRNode(nil)
+	name := "exec"
+	if h.Exec != nil {
+		command := yaml.NewListRNode()
+		for _, arg := range h.Exec.Command {
+			command.YNode().Content = append(
+				command.YNode().Content, yaml.NewStringRNode(arg).YNode())
+		}
+		if err := action.PipeE(yaml.SetField("command", command)); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	if h.HTTPGet != nil {
+		name = "httpGet"
+		if h.HTTPGet.Path != "" {
+			err := action.PipeE(
+				yaml.SetField("path", yaml.NewStringRNode(h.HTTPGet.Path)))
+			if err != nil {
+				return nil, errors.Wrap(err)
+			}
+		}
+		err := action.PipeE(yaml.SetField("port", yaml.NewRNode(&yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Value: strconv.Itoa(h.HTTPGet.Port),
+			Tag:   yaml.NodeTagInt,
+		})))
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	handler := yaml.NewMapRNode(nil)
+	return handler, errors.Wrap(handler.PipeE(yaml.SetField(name, action)))
+}
+
+// Filter adds a preStop lifecycle hook to the containers of
+// workloads that don't have one.
+type Filter struct {
+	// PreStop is the hook to add.
+	PreStop *Handler `json:"preStop,omitempty" yaml:"preStop,omitempty"`
+
+	// Containers are the names of the containers to add the hook
+	// to. If empty, the hook is added to all containers.
+	Containers []string `json:"containers,omitempty" yaml:"containers,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	selected := make(map[string]bool, len(f.Containers))
+	for _, name := range f.Containers {
+		selected[name] = true
+	}
+	return node, filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		name, err := container.GetString("name")
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if len(selected) > 0 && !selected[name] {
+			return nil
+		}
+		lifecycle, err := container.Pipe(
+			yaml.LookupCreate(yaml.MappingNode, "lifecycle"))
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if !lifecycle.Field("preStop").IsNilOrEmpty() {
+			return nil
+		}
+		hook, err := f.PreStop.node()
+		if err != nil {
+			return err
+		}
+		return errors.Wrap(lifecycle.PipeE(yaml.SetField("preStop", hook)))
+	}, "containers")
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package prestop
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"add exec hook": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        lifecycle:
+          preStop:
+            exec:
+              command:
+              - sleep
+              - "10"
+`,
+			filter: Filter{PreStop: &Handler{
+				Exec: &ExecAction{Command: []string{"sleep", "10"}}}},
+		},
+		"keep existing lifecycle": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    lifecycle:
+      postStart:
+        exec:
+          command: [warm-up]
+  - name: proxy
+    lifecycle:
+      preStop:
+        exec:
+          command: [drain]
+  - name: debug
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    lifecycle:
+      postStart:
+        exec:
+          command: [warm-up]
+      preStop:
+        httpGet:
+          path: /shutdown
+          port: 8080
+  - name: proxy
+    lifecycle:
+      preStop:
+        exec:
+          command: [drain]
+  - name: debug
+`,
+			filter: Filter{
+				PreStop: &Handler{
+					HTTPGet: &HTTPGetAction{Path: "/shutdown", Port: 8080}},
+				Containers: []string{"app", "proxy"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestHandlerValidate(t *testing.T) {
+	assert.NoError(t, (&Handler{Exec: &ExecAction{Command: []string{"sleep", "5"}}}).Validate())
+	assert.Error(t, (&Handler{}).Validate())
+	assert.Error(t, (&Handler{Exec: &ExecAction{}}).Validate())
+	assert.Error(t, (&Handler{HTTPGet: &HTTPGetAction{Path: "/"}}).Validate())
+}
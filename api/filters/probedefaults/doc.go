@@ -0,0 +1,6 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package probedefaults contains a kio.Filter implementation of the
+// kustomize ProbeDefaultsTransformer.
+package probedefaults
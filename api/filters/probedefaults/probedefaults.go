@@ -0,0 +1,138 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package probedefaults
+
+import (
+	"strconv"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Probe is a probe with either an httpGet or a tcpSocket handler.
+type Probe struct {
+	HTTPGet   *HTTPGetAction   `json:"httpGet,omitempty" yaml:"httpGet,omitempty"`
+	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty" yaml:"tcpSocket,omitempty"`
+}
+
+// HTTPGetAction probes a container with an HTTP GET request.
+type HTTPGetAction struct {
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+// TCPSocketAction probes a container by opening a TCP connection.
+type TCPSocketAction struct {
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+// Validate returns an error if the probe doesn't have exactly
+// one handler, or if the handler has no port.
+func (p *Probe) Validate() error {
+	switch {
+	case p.HTTPGet != nil && p.TCPSocket != nil:
+		return errors.Errorf("probe must not have both httpGet and tcpSocket")
+	case p.HTTPGet != nil:
+		if p.HTTPGet.Port <= 0 {
+			return errors.Errorf("httpGet probe must have a port")
+		}
+	case p.TCPSocket != nil:
+		if p.TCPSocket.Port <= 0 {
+			return errors.Errorf("tcpSocket probe must have a port")
+		}
+	default:
+		return errors.Errorf("probe must have httpGet or tcpSocket")
+	}
+	return nil
+}
+
+// node returns the probe as a yaml node.
+func (p *Probe) node() (*yaml.RNode, error) {
+	handler := yaml.NewMapRNode(nil)
+	name := "tcpSocket"
+	port := 0
+	if p.TCPSocket != nil {
+		port = p.TCPSocket.Port
+	}
+	if p.HTTPGet != nil {
+		name = "httpGet"
+		port = p.HTTPGet.Port
+		if p.HTTPGet.Path != "" {
+			err := handler.PipeE(
+				yaml.SetField("path", yaml.NewStringRNode(p.HTTPGet.Path)))
+			if err != nil {
+				return nil, errors.Wrap(err)
+			}
+		}
+	}
+	err := handler.PipeE(yaml.SetField("port", yaml.NewRNode(&yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: strconv.Itoa(port),
+		Tag:   yaml.NodeTagInt,
+	})))
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	probe := yaml.NewMapRNode(nil)
+	return probe, errors.Wrap(probe.PipeE(yaml.SetField(name, handler)))
+}
+
+// Filter adds liveness and readiness probes to the containers
+// of workloads that don't have them.
+type Filter struct {
+	// Liveness is the default liveness probe.
+	Liveness *Probe `json:"liveness,omitempty" yaml:"liveness,omitempty"`
+
+	// Readiness is the default readiness probe.
+	Readiness *Probe `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+
+	// Containers are the names of the containers to add probes
+	// to. If empty, probes are added to all containers.
+	Containers []string `json:"containers,omitempty" yaml:"containers,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	selected := make(map[string]bool, len(f.Containers))
+	for _, name := range f.Containers {
+		selected[name] = true
+	}
+	return node, filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		name, err := container.GetString("name")
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if len(selected) > 0 && !selected[name] {
+			return nil
+		}
+		if err = setProbe(container, "livenessProbe", f.Liveness); err != nil {
+			return err
+		}
+		return setProbe(container, "readinessProbe", f.Readiness)
+	}, "containers")
+}
+
+// setProbe sets the given field of the container to the probe,
+// unless the probe is nil or the container has the field already.
+func setProbe(container *yaml.RNode, field string, probe *Probe) error {
+	if probe == nil || !container.Field(field).IsNilOrEmpty() {
+		return nil
+	}
+	n, err := probe.node()
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(container.PipeE(yaml.SetField(field, n)))
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package probedefaults
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"add defaults": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8080
+        readinessProbe:
+          tcpSocket:
+            port: 8080
+`,
+			filter: Filter{
+				Liveness: &Probe{
+					HTTPGet: &HTTPGetAction{Path: "/healthz", Port: 8080}},
+				Readiness: &Probe{
+					TCPSocket: &TCPSocketAction{Port: 8080}},
+			},
+		},
+		"keep explicit probes": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    livenessProbe:
+      exec:
+        command: [check]
+  - name: sidecar
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    livenessProbe:
+      exec:
+        command: [check]
+    readinessProbe:
+      httpGet:
+        path: /ready
+        port: 80
+  - name: sidecar
+`,
+			filter: Filter{
+				Liveness: &Probe{
+					HTTPGet: &HTTPGetAction{Path: "/healthz", Port: 80}},
+				Readiness: &Probe{
+					HTTPGet: &HTTPGetAction{Path: "/ready", Port: 80}},
+				Containers: []string{"app"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestProbeValidate(t *testing.T) {
+	assert.NoError(t, (&Probe{TCPSocket: &TCPSocketAction{Port: 1}}).Validate())
+	assert.Error(t, (&Probe{}).Validate())
+	assert.Error(t, (&Probe{HTTPGet: &HTTPGetAction{Path: "/"}}).Validate())
+	assert.Error(t, (&Probe{
+		HTTPGet:   &HTTPGetAction{Port: 1},
+		TCPSocket: &TCPSocketAction{Port: 1},
+	}).Validate())
+}
@@ -0,0 +1,6 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package probetimings contains a kio.Filter implementation of the
+// kustomize ProbeTimingsTransformer.
+package probetimings
@@ -0,0 +1,74 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package probetimings
+
+import (
+	"strconv"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter sets the timing fields of the liveness, readiness and
+// startup probes of workload containers that don't set them.
+// Fields that are zero in the filter are left alone.
+type Filter struct {
+	TimeoutSeconds   int `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"`
+	PeriodSeconds    int `json:"periodSeconds,omitempty" yaml:"periodSeconds,omitempty"`
+	FailureThreshold int `json:"failureThreshold,omitempty" yaml:"failureThreshold,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	return node, filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		for _, field := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+			probe, err := container.Pipe(yaml.Lookup(field))
+			if err != nil {
+				return errors.Wrap(err)
+			}
+			if probe == nil {
+				continue
+			}
+			if err = f.setTimings(probe); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, "containers", "initContainers")
+}
+
+func (f Filter) setTimings(probe *yaml.RNode) error {
+	for _, t := range []struct {
+		field string
+		value int
+	}{
+		{"timeoutSeconds", f.TimeoutSeconds},
+		{"periodSeconds", f.PeriodSeconds},
+		{"failureThreshold", f.FailureThreshold},
+	} {
+		if t.value == 0 || !probe.Field(t.field).IsNilOrEmpty() {
+			continue
+		}
+		err := probe.PipeE(yaml.SetField(t.field, yaml.NewRNode(&yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Value: strconv.Itoa(t.value),
+			Tag:   yaml.NodeTagInt,
+		})))
+		if err != nil {
+			return errors.Wrap(err)
+		}
+	}
+	return nil
+}
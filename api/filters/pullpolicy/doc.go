@@ -0,0 +1,6 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pullpolicy contains a kio.Filter implementation of the
+// kustomize ImagePullPolicyTransformer.
+package pullpolicy
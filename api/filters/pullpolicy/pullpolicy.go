@@ -0,0 +1,50 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pullpolicy
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/image"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Filter sets the imagePullPolicy of workload containers that
+// don't set it, by the tag of their image: Always for the latest
+// tag, or no tag, since the image can change, and IfNotPresent
+// for other tags and digests.
+type Filter struct{}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	return node, filtersutil.VisitContainers(
+		podSpec, setPullPolicy, "containers", "initContainers")
+}
+
+func setPullPolicy(container *yaml.RNode) error {
+	if !container.Field("imagePullPolicy").IsNilOrEmpty() {
+		return nil
+	}
+	field := container.Field("image")
+	if field.IsNilOrEmpty() {
+		return nil
+	}
+	policy := "IfNotPresent"
+	_, tag, digest := image.Split(yaml.GetValue(field.Value))
+	if digest == "" && (tag == "" || tag == "latest") {
+		policy = "Always"
+	}
+	return errors.Wrap(container.PipeE(
+		yaml.SetField("imagePullPolicy", yaml.NewStringRNode(policy))))
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package pullpolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"policy by tag": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+      containers:
+      - name: app
+        image: app:latest
+      - name: proxy
+        image: proxy:1.2.3
+      - name: sidecar
+        image: registry.example.com:5000/sidecar@sha256:abc
+      - name: debug
+        image: debug:latest
+        imagePullPolicy: Never
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+        imagePullPolicy: Always
+      containers:
+      - name: app
+        image: app:latest
+        imagePullPolicy: Always
+      - name: proxy
+        image: proxy:1.2.3
+        imagePullPolicy: IfNotPresent
+      - name: sidecar
+        image: registry.example.com:5000/sidecar@sha256:abc
+        imagePullPolicy: IfNotPresent
+      - name: debug
+        image: debug:latest
+        imagePullPolicy: Never
+`,
+		},
+		"not a workload": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  image: app:latest
+`,
+			expected: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  image: app:latest
+`,
+		},
+		"no pod template": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, Filter{}))) {
+				t.FailNow()
+			}
+		})
+	}
+}
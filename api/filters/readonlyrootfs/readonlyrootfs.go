@@ -0,0 +1,154 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package readonlyrootfs
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	readOnlyField = "readOnlyRootFilesystem"
+	tmpVolume     = "tmp"
+	tmpPath       = "/tmp"
+)
+
+// Filter sets readOnlyRootFilesystem in the security context of
+// the containers of workloads, where it isn't set already.
+type Filter struct {
+	// Exempt are the names of the containers to leave alone.
+	Exempt []string `json:"exempt,omitempty" yaml:"exempt,omitempty"`
+
+	// WritableTmp mounts an emptyDir volume at /tmp in the containers
+	// whose root filesystem ends up read-only, unless they already
+	// mount something there. The volume named tmp is used if it is an
+	// emptyDir; otherwise a new emptyDir volume with a free name is added.
+	WritableTmp bool `json:"writableTmp,omitempty" yaml:"writableTmp,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec, err := filtersutil.LookupPodSpec(node)
+	if err != nil || podSpec == nil {
+		return node, err
+	}
+	exempt := make(map[string]bool, len(f.Exempt))
+	for _, name := range f.Exempt {
+		exempt[name] = true
+	}
+	var readOnly []*yaml.RNode
+	err = filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		name, err := container.GetString("name")
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		if exempt[name] {
+			return nil
+		}
+		sc, err := container.Pipe(
+			yaml.LookupCreate(yaml.MappingNode, "securityContext"))
+		if err != nil {
+			return errors.Wrap(err)
+		}
+		field := sc.Field(readOnlyField)
+		if field.IsNilOrEmpty() {
+			if err = sc.PipeE(yaml.SetField(readOnlyField, trueNode())); err != nil {
+				return errors.Wrap(err)
+			}
+			readOnly = append(readOnly, container)
+		} else if yaml.GetValue(field.Value) == "true" {
+			readOnly = append(readOnly, container)
+		}
+		return nil
+	}, "containers", "initContainers")
+	if err != nil {
+		return nil, err
+	}
+	if !f.WritableTmp {
+		return node, nil
+	}
+	var needMount []*yaml.RNode
+	for _, container := range readOnly {
+		mounts, err := container.Pipe(yaml.Lookup("volumeMounts"))
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		if mounts == nil || mounts.Element("mountPath", tmpPath) == nil {
+			needMount = append(needMount, container)
+		}
+	}
+	if len(needMount) == 0 {
+		return node, nil
+	}
+	volume, err := tmpVolumeName(podSpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range needMount {
+		if err = mountTmp(container, volume); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// tmpVolumeName returns the name of an emptyDir volume of the pod
+// spec to mount at /tmp: the tmp volume if it is an emptyDir, or
+// else a new emptyDir volume named tmp, or tmp-1, tmp-2 and so on,
+// whichever name is free.
+func tmpVolumeName(podSpec *yaml.RNode) (string, error) {
+	volumes, err := podSpec.Pipe(yaml.LookupCreate(yaml.SequenceNode, "volumes"))
+	if err != nil {
+		return "", errors.Wrap(err)
+	}
+	name := tmpVolume
+	for i := 1; ; i++ {
+		existing := volumes.Element("name", name)
+		if existing == nil {
+			break
+		}
+		if existing.Field("emptyDir") != nil {
+			return name, nil
+		}
+		name = fmt.Sprintf("%s-%d", tmpVolume, i)
+	}
+	volume := yaml.NewMapRNode(&map[string]string{"name": name})
+	if err = volume.PipeE(
+		yaml.SetField("emptyDir", yaml.NewMapRNode(nil))); err != nil {
+		return "", errors.Wrap(err)
+	}
+	return name, errors.Wrap(volumes.PipeE(yaml.Append(volume.YNode())))
+}
+
+// mountTmp mounts the volume at /tmp in the container.
+func mountTmp(container *yaml.RNode, volume string) error {
+	mounts, err := container.Pipe(
+		yaml.LookupCreate(yaml.SequenceNode, "volumeMounts"))
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	mount := yaml.NewMapRNode(&map[string]string{"name": volume})
+	if err = mount.PipeE(
+		yaml.SetField("mountPath", yaml.NewStringRNode(tmpPath))); err != nil {
+		return errors.Wrap(err)
+	}
+	return errors.Wrap(mounts.PipeE(yaml.Append(mount.YNode())))
+}
+
+func trueNode() *yaml.RNode {
+	return yaml.NewRNode(&yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: "true",
+		Tag:   yaml.NodeTagBool,
+	})
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package readonlyrootfs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"harden and exempt": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+      - name: cache
+        image: redis
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        securityContext:
+          readOnlyRootFilesystem: true
+      - name: cache
+        image: redis
+`,
+			filter: Filter{Exempt: []string{"cache"}},
+		},
+		"keep explicit value": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    securityContext:
+      readOnlyRootFilesystem: false
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    securityContext:
+      readOnlyRootFilesystem: false
+`,
+		},
+		"writable tmp": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+  - name: other
+    volumeMounts:
+    - name: scratch
+      mountPath: /tmp
+  volumes:
+  - name: scratch
+    emptyDir: {}
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    securityContext:
+      readOnlyRootFilesystem: true
+    volumeMounts:
+    - name: tmp
+      mountPath: /tmp
+  - name: other
+    volumeMounts:
+    - name: scratch
+      mountPath: /tmp
+    securityContext:
+      readOnlyRootFilesystem: true
+  volumes:
+  - name: scratch
+    emptyDir: {}
+  - name: tmp
+    emptyDir: {}
+`,
+			filter: Filter{WritableTmp: true},
+		},
+		"writable tmp skips writable containers": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    securityContext:
+      readOnlyRootFilesystem: false
+  - name: debug
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    securityContext:
+      readOnlyRootFilesystem: false
+  - name: debug
+`,
+			filter: Filter{Exempt: []string{"debug"}, WritableTmp: true},
+		},
+		"writable tmp with a tmp volume that isn't an emptyDir": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        volumeMounts:
+        - name: tmp
+          mountPath: /etc/tmp
+      volumes:
+      - name: tmp
+        configMap:
+          name: tmp-config
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        volumeMounts:
+        - name: tmp
+          mountPath: /etc/tmp
+        - name: tmp-1
+          mountPath: /tmp
+        securityContext:
+          readOnlyRootFilesystem: true
+      volumes:
+      - name: tmp
+        configMap:
+          name: tmp-config
+      - name: tmp-1
+        emptyDir: {}
+`,
+			filter: Filter{WritableTmp: true},
+		},
+		"writable tmp reuses a tmp emptyDir": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+  volumes:
+  - name: tmp
+    emptyDir:
+      medium: Memory
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+    securityContext:
+      readOnlyRootFilesystem: true
+    volumeMounts:
+    - name: tmp
+      mountPath: /tmp
+  volumes:
+  - name: tmp
+    emptyDir:
+      medium: Memory
+`,
+			filter: Filter{WritableTmp: true},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
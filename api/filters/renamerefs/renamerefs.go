@@ -0,0 +1,106 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package renamerefs
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/filters/fsslice"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// referencePaths maps the kinds whose references can be renamed to
+// the paths, relative to a pod spec, of the fields referring to them.
+var referencePaths = map[string][]string{ //nolint:gochecknoglobals
+	"ConfigMap": {
+		"volumes/configMap/name",
+		"volumes/projected/sources/configMap/name",
+		"containers/envFrom/configMapRef/name",
+		"containers/env/valueFrom/configMapKeyRef/name",
+		"initContainers/envFrom/configMapRef/name",
+		"initContainers/env/valueFrom/configMapKeyRef/name",
+	},
+	"Secret": {
+		"volumes/secret/secretName",
+		"volumes/projected/sources/secret/name",
+		"containers/envFrom/secretRef/name",
+		"containers/env/valueFrom/secretKeyRef/name",
+		"initContainers/envFrom/secretRef/name",
+		"initContainers/env/valueFrom/secretKeyRef/name",
+		"imagePullSecrets/name",
+	},
+}
+
+// Rename declares that the object of the given kind named From
+// is now named To.
+type Rename struct {
+	// Kind is either ConfigMap or Secret.
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	To   string `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// Validate returns an error if the rename can't be applied.
+func (r Rename) Validate() error {
+	if _, ok := referencePaths[r.Kind]; !ok {
+		return errors.Errorf(
+			"kind %q of rename %q must be ConfigMap or Secret", r.Kind, r.From)
+	}
+	if r.From == "" || r.To == "" {
+		return errors.Errorf(
+			"rename of %s must specify both from and to", r.Kind)
+	}
+	return nil
+}
+
+// Filter rewrites the references that the pod specs of workloads
+// make to renamed ConfigMaps and Secrets.
+type Filter struct {
+	Renames []Rename `json:"renames,omitempty" yaml:"renames,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	podSpec := filtersutil.PodSpecPath(node.GetKind())
+	if podSpec == nil {
+		return node, nil
+	}
+	prefix := strings.Join(podSpec, "/") + "/"
+	for _, r := range f.Renames {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		var fsSlice types.FsSlice
+		for _, path := range referencePaths[r.Kind] {
+			fsSlice = append(fsSlice, types.FieldSpec{Path: prefix + path})
+		}
+		if err := node.PipeE(fsslice.Filter{
+			FsSlice:  fsSlice,
+			SetValue: rename(r.From, r.To),
+		}); err != nil {
+			return nil, errors.Wrap(err)
+		}
+	}
+	return node, nil
+}
+
+// rename returns a SetFn replacing the value from with to.
+func rename(from, to string) filtersutil.SetFn {
+	return func(node *yaml.RNode) error {
+		if node.YNode().Value == from {
+			node.YNode().Value = to
+		}
+		return nil
+	}
+}
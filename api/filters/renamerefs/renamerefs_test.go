@@ -0,0 +1,164 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package renamerefs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	filtertest_test "sigs.k8s.io/kustomize/api/testutils/filtertest"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		filter   Filter
+	}{
+		"configmap references": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        envFrom:
+        - configMapRef:
+            name: old
+        env:
+        - name: A
+          valueFrom:
+            configMapKeyRef:
+              name: old
+              key: a
+        - name: B
+          valueFrom:
+            configMapKeyRef:
+              name: unrelated
+              key: b
+      volumes:
+      - name: v1
+        configMap:
+          name: old
+      - name: v2
+        projected:
+          sources:
+          - configMap:
+              name: old
+          - secret:
+              name: old
+`,
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        envFrom:
+        - configMapRef:
+            name: new
+        env:
+        - name: A
+          valueFrom:
+            configMapKeyRef:
+              name: new
+              key: a
+        - name: B
+          valueFrom:
+            configMapKeyRef:
+              name: unrelated
+              key: b
+      volumes:
+      - name: v1
+        configMap:
+          name: new
+      - name: v2
+        projected:
+          sources:
+          - configMap:
+              name: new
+          - secret:
+              name: old
+`,
+			filter: Filter{Renames: []Rename{
+				{Kind: "ConfigMap", From: "old", To: "new"},
+			}},
+		},
+		"secret references": {
+			input: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  initContainers:
+  - name: init
+    envFrom:
+    - secretRef:
+        name: old
+    - configMapRef:
+        name: old
+  volumes:
+  - name: v
+    secret:
+      secretName: old
+`,
+			expected: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  initContainers:
+  - name: init
+    envFrom:
+    - secretRef:
+        name: new
+    - configMapRef:
+        name: old
+  volumes:
+  - name: v
+    secret:
+      secretName: new
+`,
+			filter: Filter{Renames: []Rename{
+				{Kind: "Secret", From: "old", To: "new"},
+			}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !assert.Equal(t,
+				strings.TrimSpace(tc.expected),
+				strings.TrimSpace(
+					filtertest_test.RunFilter(t, tc.input, tc.filter))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestFilterInvalidRename(t *testing.T) {
+	_, err := Filter{Renames: []Rename{{Kind: "Service", From: "a", To: "b"}}}.
+		Filter([]*yaml.RNode{yaml.MustParse(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+`)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `kind "Service" of rename "a" must be ConfigMap or Secret`)
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package serviceaccount
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const serviceAccountNameField = "serviceAccountName"
+
+// Filter sets the serviceAccountName of the pod spec of workloads,
+// replacing any name that is already set.
+type Filter struct {
+	// Name is the name of the ServiceAccount the pods run as.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+var _ kio.Filter = Filter{}
+
+func (f Filter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	return kio.FilterAll(yaml.FilterFunc(f.run)).Filter(nodes)
+}
+
+func (f Filter) run(node *yaml.RNode) (*yaml.RNode, error) {
+	if f.Name == "" {
+		return node, nil
+	}
+	path := filtersutil.PodSpecPath(node.GetKind())
+	if path == nil {
+		return node, nil
+	}
+	podSpec, err := node.Pipe(yaml.LookupCreate(yaml.MappingNode, path...))
+	if err != nil {
+		return node, errors.Wrap(err)
+	}
+	err = podSpec.PipeE(yaml.SetField(
+		serviceAccountNameField, yaml.NewStringRNode(f.Name)))
+	return node, errors.Wrap(err)
+}
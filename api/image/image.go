@@ -64,3 +64,36 @@ func Split(imageName string) (name string, tag string, digest string) {
 	tag = strings.TrimPrefix(imageName[ic:], ":")
 	return name, tag, ""
 }
+
+// Canonical returns the fully-qualified form of the image
+// reference, as the container runtime resolves it: images without
+// a registry are on docker.io, official docker.io images are in
+// the library repository, and images without a tag or digest
+// have the tag latest. E.g. nginx is docker.io/library/nginx:latest.
+func Canonical(imageName string) string {
+	name, tag, digest := Split(imageName)
+	domain, remainder := "docker.io", name
+	if i := strings.Index(name, "/"); i >= 0 {
+		first := name[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			domain, remainder = first, name[i+1:]
+		}
+	}
+	if domain == "index.docker.io" {
+		domain = "docker.io"
+	}
+	if domain == "docker.io" && !strings.Contains(remainder, "/") {
+		remainder = "library/" + remainder
+	}
+	result := domain + "/" + remainder
+	if tag == "" && digest == "" {
+		tag = "latest"
+	}
+	if tag != "" {
+		result += ":" + tag
+	}
+	if digest != "" {
+		result += "@" + digest
+	}
+	return result
+}
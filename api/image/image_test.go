@@ -123,3 +123,22 @@ func TestSplit(t *testing.T) {
 		})
 	}
 }
+
+func TestCanonical(t *testing.T) {
+	testCases := map[string]string{
+		"nginx":                                "docker.io/library/nginx:latest",
+		"nginx:1.25":                           "docker.io/library/nginx:1.25",
+		"nginx@sha256:12345":                   "docker.io/library/nginx@sha256:12345",
+		"bitnami/redis":                        "docker.io/bitnami/redis:latest",
+		"index.docker.io/nginx:1.25":           "docker.io/library/nginx:1.25",
+		"docker.io/library/nginx:latest":       "docker.io/library/nginx:latest",
+		"gcr.io/project/app:v1":                "gcr.io/project/app:v1",
+		"localhost/app":                        "localhost/app:latest",
+		"foo.com:443/nginx:1.2.3@sha256:12345": "foo.com:443/nginx:1.2.3@sha256:12345",
+	}
+	for value, expected := range testCases {
+		t.Run(value, func(t *testing.T) {
+			assert.Equal(t, expected, Canonical(value))
+		})
+	}
+}
@@ -5,6 +5,7 @@ package builtins
 
 import (
 	"fmt"
+	"regexp"
 
 	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/resmap"
@@ -14,6 +15,9 @@ type HashTransformerPlugin struct {
 	hasher ifc.KustHasher
 }
 
+// dnsLabel matches the hashes that can be appended to names.
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`) //nolint:gochecknoglobals
+
 func (p *HashTransformerPlugin) Config(
 	h *resmap.PluginHelpers, _ []byte) (err error) {
 	p.hasher = h.ResmapFactory().RF().Hasher()
@@ -28,6 +32,11 @@ func (p *HashTransformerPlugin) Transform(m resmap.ResMap) error {
 			if err != nil {
 				return err
 			}
+			if !dnsLabel.MatchString(h) {
+				return fmt.Errorf(
+					"hash %q of %s is not a valid name suffix; it must be a DNS label",
+					h, res.CurId())
+			}
 			res.StorePreviousId()
 			res.SetName(fmt.Sprintf("%s-%s", res.GetName(), h))
 		}
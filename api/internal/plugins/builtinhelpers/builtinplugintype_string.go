@@ -27,11 +27,34 @@ func _() {
 	_ = x[ValueAddTransformer-16]
 	_ = x[HelmChartInflationGenerator-17]
 	_ = x[ReplacementTransformer-18]
+	_ = x[FsGroupTransformer-19]
+	_ = x[CronJobMigrationTransformer-20]
+	_ = x[ImagePullSecretsTransformer-21]
+	_ = x[VersionLabelTransformer-22]
+	_ = x[RenameReferencesTransformer-23]
+	_ = x[FieldToMetadataTransformer-24]
+	_ = x[EnvToFileTransformer-25]
+	_ = x[ReadOnlyRootFilesystemTransformer-26]
+	_ = x[ContainerPortNamesTransformer-27]
+	_ = x[ProbeDefaultsTransformer-28]
+	_ = x[ImageDigestLockTransformer-29]
+	_ = x[DefaultDenyNetworkPolicyTransformer-30]
+	_ = x[HostAliasesTransformer-31]
+	_ = x[LabelRemovalTransformer-32]
+	_ = x[MetadataMoveTransformer-33]
+	_ = x[ChildNameTransformer-34]
+	_ = x[PreStopHookTransformer-35]
+	_ = x[DataMoveTransformer-36]
+	_ = x[ExternalNameTransformer-37]
+	_ = x[ProbeTimingsTransformer-38]
+	_ = x[ImagePullPolicyTransformer-39]
+	_ = x[ServiceAccountRbacTransformer-40]
+	_ = x[VolumeClaimStorageTransformer-41]
 }
 
-const _BuiltinPluginType_name = "UnknownAnnotationsTransformerConfigMapGeneratorIAMPolicyGeneratorHashTransformerImageTagTransformerLabelTransformerNamespaceTransformerPatchJson6902TransformerPatchStrategicMergeTransformerPatchTransformerPrefixSuffixTransformerPrefixTransformerSuffixTransformerReplicaCountTransformerSecretGeneratorValueAddTransformerHelmChartInflationGeneratorReplacementTransformer"
+const _BuiltinPluginType_name = "UnknownAnnotationsTransformerConfigMapGeneratorIAMPolicyGeneratorHashTransformerImageTagTransformerLabelTransformerNamespaceTransformerPatchJson6902TransformerPatchStrategicMergeTransformerPatchTransformerPrefixSuffixTransformerPrefixTransformerSuffixTransformerReplicaCountTransformerSecretGeneratorValueAddTransformerHelmChartInflationGeneratorReplacementTransformerFsGroupTransformerCronJobMigrationTransformerImagePullSecretsTransformerVersionLabelTransformerRenameReferencesTransformerFieldToMetadataTransformerEnvToFileTransformerReadOnlyRootFilesystemTransformerContainerPortNamesTransformerProbeDefaultsTransformerImageDigestLockTransformerDefaultDenyNetworkPolicyTransformerHostAliasesTransformerLabelRemovalTransformerMetadataMoveTransformerChildNameTransformerPreStopHookTransformerDataMoveTransformerExternalNameTransformerProbeTimingsTransformerImagePullPolicyTransformerServiceAccountRbacTransformerVolumeClaimStorageTransformer"
 
-var _BuiltinPluginType_index = [...]uint16{0, 7, 29, 47, 65, 80, 99, 115, 135, 159, 189, 205, 228, 245, 262, 285, 300, 319, 346, 368}
+var _BuiltinPluginType_index = [...]uint16{0, 7, 29, 47, 65, 80, 99, 115, 135, 159, 189, 205, 228, 245, 262, 285, 300, 319, 346, 368, 386, 413, 440, 463, 490, 516, 536, 569, 598, 622, 648, 683, 705, 728, 751, 771, 793, 812, 835, 858, 884, 913, 942}
 
 func (i BuiltinPluginType) String() string {
 	if i < 0 || i >= BuiltinPluginType(len(_BuiltinPluginType_index)-1) {
@@ -31,6 +31,29 @@ const (
 	ValueAddTransformer
 	HelmChartInflationGenerator
 	ReplacementTransformer
+	FsGroupTransformer
+	CronJobMigrationTransformer
+	ImagePullSecretsTransformer
+	VersionLabelTransformer
+	RenameReferencesTransformer
+	FieldToMetadataTransformer
+	EnvToFileTransformer
+	ReadOnlyRootFilesystemTransformer
+	ContainerPortNamesTransformer
+	ProbeDefaultsTransformer
+	ImageDigestLockTransformer
+	DefaultDenyNetworkPolicyTransformer
+	HostAliasesTransformer
+	LabelRemovalTransformer
+	MetadataMoveTransformer
+	ChildNameTransformer
+	PreStopHookTransformer
+	DataMoveTransformer
+	ExternalNameTransformer
+	ProbeTimingsTransformer
+	ImagePullPolicyTransformer
+	ServiceAccountRbacTransformer
+	VolumeClaimStorageTransformer
 )
 
 var stringToBuiltinPluginTypeMap map[string]BuiltinPluginType
@@ -95,20 +118,43 @@ func NewMultiTransformer() resmap.TransformerPlugin {
 }
 
 var TransformerFactories = map[BuiltinPluginType]func() resmap.TransformerPlugin{
-	AnnotationsTransformer:         builtins.NewAnnotationsTransformerPlugin,
-	HashTransformer:                builtins.NewHashTransformerPlugin,
-	ImageTagTransformer:            builtins.NewImageTagTransformerPlugin,
-	LabelTransformer:               builtins.NewLabelTransformerPlugin,
-	NamespaceTransformer:           builtins.NewNamespaceTransformerPlugin,
-	PatchJson6902Transformer:       builtins.NewPatchJson6902TransformerPlugin,
-	PatchStrategicMergeTransformer: builtins.NewPatchStrategicMergeTransformerPlugin,
-	PatchTransformer:               builtins.NewPatchTransformerPlugin,
-	PrefixSuffixTransformer:        NewMultiTransformer,
-	PrefixTransformer:              builtins.NewPrefixTransformerPlugin,
-	SuffixTransformer:              builtins.NewSuffixTransformerPlugin,
-	ReplacementTransformer:         builtins.NewReplacementTransformerPlugin,
-	ReplicaCountTransformer:        builtins.NewReplicaCountTransformerPlugin,
-	ValueAddTransformer:            builtins.NewValueAddTransformerPlugin,
+	AnnotationsTransformer:              builtins.NewAnnotationsTransformerPlugin,
+	HashTransformer:                     builtins.NewHashTransformerPlugin,
+	ImageTagTransformer:                 builtins.NewImageTagTransformerPlugin,
+	LabelTransformer:                    builtins.NewLabelTransformerPlugin,
+	NamespaceTransformer:                builtins.NewNamespaceTransformerPlugin,
+	PatchJson6902Transformer:            builtins.NewPatchJson6902TransformerPlugin,
+	PatchStrategicMergeTransformer:      builtins.NewPatchStrategicMergeTransformerPlugin,
+	PatchTransformer:                    builtins.NewPatchTransformerPlugin,
+	PrefixSuffixTransformer:             NewMultiTransformer,
+	PrefixTransformer:                   builtins.NewPrefixTransformerPlugin,
+	SuffixTransformer:                   builtins.NewSuffixTransformerPlugin,
+	ReplacementTransformer:              builtins.NewReplacementTransformerPlugin,
+	ReplicaCountTransformer:             builtins.NewReplicaCountTransformerPlugin,
+	ValueAddTransformer:                 builtins.NewValueAddTransformerPlugin,
+	FsGroupTransformer:                  builtins.NewFsGroupTransformerPlugin,
+	CronJobMigrationTransformer:         builtins.NewCronJobMigrationTransformerPlugin,
+	ImagePullSecretsTransformer:         builtins.NewImagePullSecretsTransformerPlugin,
+	VersionLabelTransformer:             builtins.NewVersionLabelTransformerPlugin,
+	RenameReferencesTransformer:         builtins.NewRenameReferencesTransformerPlugin,
+	FieldToMetadataTransformer:          builtins.NewFieldToMetadataTransformerPlugin,
+	EnvToFileTransformer:                builtins.NewEnvToFileTransformerPlugin,
+	ReadOnlyRootFilesystemTransformer:   builtins.NewReadOnlyRootFilesystemTransformerPlugin,
+	ContainerPortNamesTransformer:       builtins.NewContainerPortNamesTransformerPlugin,
+	ProbeDefaultsTransformer:            builtins.NewProbeDefaultsTransformerPlugin,
+	ImageDigestLockTransformer:          builtins.NewImageDigestLockTransformerPlugin,
+	DefaultDenyNetworkPolicyTransformer: builtins.NewDefaultDenyNetworkPolicyTransformerPlugin,
+	HostAliasesTransformer:              builtins.NewHostAliasesTransformerPlugin,
+	LabelRemovalTransformer:             builtins.NewLabelRemovalTransformerPlugin,
+	MetadataMoveTransformer:             builtins.NewMetadataMoveTransformerPlugin,
+	ChildNameTransformer:                builtins.NewChildNameTransformerPlugin,
+	PreStopHookTransformer:              builtins.NewPreStopHookTransformerPlugin,
+	DataMoveTransformer:                 builtins.NewDataMoveTransformerPlugin,
+	ExternalNameTransformer:             builtins.NewExternalNameTransformerPlugin,
+	ProbeTimingsTransformer:             builtins.NewProbeTimingsTransformerPlugin,
+	ImagePullPolicyTransformer:          builtins.NewImagePullPolicyTransformerPlugin,
+	ServiceAccountRbacTransformer:       builtins.NewServiceAccountRbacTransformerPlugin,
+	VolumeClaimStorageTransformer:       builtins.NewVolumeClaimStorageTransformerPlugin,
 	// Do not wired SortOrderTransformer as a builtin plugin.
 	// We only want it to be available in the top-level kustomization.
 	// See: https://github.com/kubernetes-sigs/kustomize/issues/3913
@@ -22,7 +22,7 @@ import (
 
 const (
 	idAnnotation       = "kustomize.config.k8s.io/id"
-	HashAnnotation     = "kustomize.config.k8s.io/needs-hash"
+	HashAnnotation     = konfig.NeedsHashAnnotation
 	BehaviorAnnotation = "kustomize.config.k8s.io/behavior"
 )
 
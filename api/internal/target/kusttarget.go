@@ -36,6 +36,7 @@ type KustTarget struct {
 	rFactory      *resmap.Factory
 	pLdr          *loader.Loader
 	origin        *resource.Origin
+	timings       *types.BuildTimings
 }
 
 // NewKustTarget returns a new instance of KustTarget.
@@ -52,6 +53,21 @@ func NewKustTarget(
 	}
 }
 
+// SetTimings makes the target record the time taken by the
+// phases of the build, and by each transformer, in t.
+func (kt *KustTarget) SetTimings(t *types.BuildTimings) {
+	kt.timings = t
+}
+
+// timed runs f, adding the time it takes to the given phase
+// if the target records timings.
+func (kt *KustTarget) timed(phase types.BuildPhase, f func() error) error {
+	if kt.timings == nil {
+		return f()
+	}
+	return kt.timings.Time(phase, f)
+}
+
 // Load attempts to load the target's kustomization file.
 func (kt *KustTarget) Load() error {
 	content, kustFileName, err := LoadKustFile(kt.ldr)
@@ -135,20 +151,18 @@ func (kt *KustTarget) makeCustomizedResMap() (resmap.ResMap, error) {
 	// The following steps must be done last, not as part of
 	// the recursion implicit in AccumulateTarget.
 
-	err = kt.addHashesToNames(ra)
-	if err != nil {
-		return nil, err
-	}
-
-	// Given that names have changed (prefixs/suffixes added),
-	// fix all the back references to those names.
-	err = ra.FixBackReferences()
-	if err != nil {
-		return nil, err
-	}
-
-	// With all the back references fixed, it's OK to resolve Vars.
-	err = ra.ResolveVars()
+	err = kt.timed(types.BuildPhaseTransform, func() error {
+		if err := kt.addHashesToNames(ra); err != nil {
+			return err
+		}
+		// Given that names have changed (prefixs/suffixes added),
+		// fix all the back references to those names.
+		if err := ra.FixBackReferences(); err != nil {
+			return err
+		}
+		// With all the back references fixed, it's OK to resolve Vars.
+		return ra.ResolveVars()
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -269,7 +283,11 @@ func (kt *KustTarget) runGenerators(
 	}
 	generators = append(generators, gs...)
 	for i, g := range generators {
-		resMap, err := g.Generate()
+		var resMap resmap.ResMap
+		err = kt.timed(types.BuildPhaseGenerate, func() (err error) {
+			resMap, err = g.Generate()
+			return err
+		})
 		if err != nil {
 			return err
 		}
@@ -331,7 +349,9 @@ func (kt *KustTarget) runTransformers(ra *accumulator.ResAccumulator) error {
 		return err
 	}
 	r = append(r, lts...)
-	return ra.Transform(newMultiTransformer(r))
+	return kt.timed(types.BuildPhaseTransform, func() error {
+		return ra.Transform(newMultiTransformer(r, kt.timings))
+	})
 }
 
 func (kt *KustTarget) configureExternalTransformers(transformers []string) ([]*resmap.TransformerWithProperties, error) {
@@ -476,7 +496,8 @@ func (kt *KustTarget) accumulateDirectory(
 	ra *accumulator.ResAccumulator, ldr ifc.Loader, isComponent bool) (*accumulator.ResAccumulator, error) {
 	defer ldr.Cleanup()
 	subKt := NewKustTarget(ldr, kt.validator, kt.rFactory, kt.pLdr)
-	err := subKt.Load()
+	subKt.timings = kt.timings
+	err := kt.timed(types.BuildPhaseLoad, subKt.Load)
 	if err != nil {
 		return nil, errors.WrapPrefixf(
 			err, "couldn't make target for path '%s'", ldr.Root())
@@ -526,7 +547,11 @@ func (kt *KustTarget) accumulateDirectory(
 
 func (kt *KustTarget) accumulateFile(
 	ra *accumulator.ResAccumulator, path string) error {
-	resources, err := kt.rFactory.FromFile(kt.ldr, path)
+	var resources resmap.ResMap
+	err := kt.timed(types.BuildPhaseLoad, func() (err error) {
+		resources, err = kt.rFactory.FromFile(kt.ldr, path)
+		return err
+	})
 	if err != nil {
 		return errors.WrapPrefixf(err, "accumulating resources from '%s'", path)
 	}
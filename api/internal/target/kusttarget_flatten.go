@@ -0,0 +1,343 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package target
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/internal/accumulator"
+	"sigs.k8s.io/kustomize/api/internal/git"
+	"sigs.k8s.io/kustomize/api/internal/plugins/builtinconfig"
+	"sigs.k8s.io/kustomize/api/internal/plugins/builtinhelpers"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/loader"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// Flatten returns a kustomization listing the resource files
+// of the target and of all the bases and components it includes,
+// recursively, followed by their generators, transformers,
+// validators and patches, in the order kustomize runs them.
+// Builtin fields such as configMapGenerator, images or namePrefix
+// become inline configs of the matching builtin plugins.
+// Local paths are made relative to the root of the target.
+//
+// It is meant to show the effective configuration of a target;
+// every transformer of the result applies to the whole tree, e.g.
+// the namePrefix of a base also renames the resources of the overlay,
+// so building the result doesn't in general give the same output as
+// building the target. Fields that can't be expressed as a builtin
+// plugin config, e.g. vars, make Flatten fail.
+// The target must be loaded.
+func (kt *KustTarget) Flatten() (*types.Kustomization, error) {
+	result := &types.Kustomization{
+		TypeMeta: types.TypeMeta{
+			APIVersion: types.KustomizationVersion,
+			Kind:       types.KustomizationKind,
+		},
+	}
+	if _, err := kt.flattenInto(result, ""); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// flattenInto appends the flattened form of the target to result.
+// dir is the path of the target's root relative to the top one.
+// It returns the transformer config of the target, merged with the
+// ones of the targets it includes, as the build would accumulate it.
+func (kt *KustTarget) flattenInto(
+	result *types.Kustomization, dir string) (*builtinconfig.TransformerConfig, error) {
+	k := kt.kustomization
+	if err := checkFlattenable(k); err != nil {
+		return nil, err
+	}
+	tc := builtinconfig.MakeEmptyConfig()
+	for _, path := range k.Resources {
+		if _, err := kt.ldr.Load(path); err == nil {
+			result.Resources = append(result.Resources, rebase(dir, path))
+			continue
+		}
+		subTc, err := kt.flattenSubTarget(result, dir, path)
+		if err != nil {
+			return nil, err
+		}
+		if tc, err = tc.Merge(subTc); err != nil {
+			return nil, err
+		}
+	}
+	for _, path := range k.Components {
+		subTc, err := kt.flattenSubTarget(result, dir, path)
+		if err != nil {
+			return nil, err
+		}
+		if tc, err = tc.Merge(subTc); err != nil {
+			return nil, err
+		}
+	}
+	ownTc, err := builtinconfig.MakeTransformerConfig(kt.ldr, k.Configurations)
+	if err != nil {
+		return nil, err
+	}
+	if tc, err = tc.Merge(ownTc); err != nil {
+		return nil, err
+	}
+	crdTc, err := accumulator.LoadConfigFromCRDs(kt.ldr, k.Crds)
+	if err != nil {
+		return nil, errors.WrapPrefixf(err, "loading CRDs %v", k.Crds)
+	}
+	if tc, err = tc.Merge(crdTc); err != nil {
+		return nil, err
+	}
+	if err = kt.flattenBuiltins(result, dir, tc); err != nil {
+		return nil, err
+	}
+	result.Generators = append(result.Generators, kt.rebasePlugins(dir, k.Generators)...)
+	result.Transformers = append(result.Transformers, kt.rebasePlugins(dir, k.Transformers)...)
+	result.Validators = append(result.Validators, kt.rebasePlugins(dir, k.Validators)...)
+	for _, p := range k.Patches {
+		if p.Path != "" {
+			p.Path = rebase(dir, p.Path)
+		}
+		result.Patches = append(result.Patches, p)
+	}
+	return tc, nil
+}
+
+// checkFlattenable returns an error naming the first field of k
+// that has no builtin plugin counterpart.
+func checkFlattenable(k *types.Kustomization) error {
+	for field, set := range map[string]bool{
+		"openapi":                     len(k.OpenAPI) > 0,
+		"vars":                        len(k.Vars) > 0,
+		"sortOptions":                 k.SortOptions != nil,
+		"helmGlobals":                 k.HelmGlobals != nil,
+		"helmCharts":                  len(k.HelmCharts) > 0,
+		"helmChartInflationGenerator": len(k.HelmChartInflationGenerator) > 0,
+		"buildMetadata":               len(k.BuildMetadata) > 0,
+	} {
+		if set {
+			return fmt.Errorf("cannot flatten a kustomization that sets %s", field)
+		}
+	}
+	return nil
+}
+
+func (kt *KustTarget) flattenSubTarget(
+	result *types.Kustomization, dir, path string) (*builtinconfig.TransformerConfig, error) {
+	ldr, err := kt.ldr.New(path)
+	if err != nil {
+		return nil, errors.WrapPrefixf(err, "flattening %q", path)
+	}
+	defer ldr.Cleanup()
+	subKt := NewKustTarget(ldr, kt.validator, kt.rFactory, kt.pLdr)
+	if err = subKt.Load(); err != nil {
+		return nil, errors.WrapPrefixf(err, "flattening %q", path)
+	}
+	return subKt.flattenInto(result, rebase(dir, path))
+}
+
+// flattenBuiltins appends to result an inline config for each
+// builtin generator and transformer the build would configure from
+// the fields of the target, except patches, which stay patches.
+// The configs are made by the same configurators the build uses.
+func (kt *KustTarget) flattenBuiltins(
+	result *types.Kustomization, dir string, tc *builtinconfig.TransformerConfig) error {
+	rebased := *kt
+	rebased.kustomization = kt.rebasedKustomization(dir)
+	for _, bpt := range []builtinhelpers.BuiltinPluginType{
+		builtinhelpers.ConfigMapGenerator,
+		builtinhelpers.SecretGenerator,
+	} {
+		gs, err := generatorConfigurators[bpt](
+			&rebased, bpt, func() resmap.GeneratorPlugin { return &configRecorder{} })
+		if err != nil {
+			return err
+		}
+		for _, g := range gs {
+			c, err := inlineBuiltinConfig(
+				bpt, len(result.Generators), g.(*configRecorder).config)
+			if err != nil {
+				return err
+			}
+			result.Generators = append(result.Generators, c)
+		}
+	}
+	for _, bpt := range []builtinhelpers.BuiltinPluginType{
+		builtinhelpers.PatchStrategicMergeTransformer,
+		builtinhelpers.NamespaceTransformer,
+		builtinhelpers.PrefixTransformer,
+		builtinhelpers.SuffixTransformer,
+		builtinhelpers.LabelTransformer,
+		builtinhelpers.AnnotationsTransformer,
+		builtinhelpers.PatchJson6902Transformer,
+		builtinhelpers.ReplicaCountTransformer,
+		builtinhelpers.ImageTagTransformer,
+		builtinhelpers.ReplacementTransformer,
+	} {
+		ts, err := transformerConfigurators[bpt](
+			&rebased, bpt, func() resmap.TransformerPlugin { return &configRecorder{} }, tc)
+		if err != nil {
+			return err
+		}
+		for _, t := range ts {
+			c, err := inlineBuiltinConfig(
+				bpt, len(result.Transformers), t.(*configRecorder).config)
+			if err != nil {
+				return err
+			}
+			result.Transformers = append(result.Transformers, c)
+		}
+	}
+	return nil
+}
+
+// rebasedKustomization returns a copy of the kustomization of the
+// target with the paths read by builtin plugins rebased on dir.
+func (kt *KustTarget) rebasedKustomization(dir string) *types.Kustomization {
+	k := *kt.kustomization
+	k.ConfigMapGenerator = make([]types.ConfigMapArgs, len(kt.kustomization.ConfigMapGenerator))
+	for i, args := range kt.kustomization.ConfigMapGenerator {
+		args.KvPairSources = rebaseKvPairSources(dir, args.KvPairSources)
+		k.ConfigMapGenerator[i] = args
+	}
+	k.SecretGenerator = make([]types.SecretArgs, len(kt.kustomization.SecretGenerator))
+	for i, args := range kt.kustomization.SecretGenerator {
+		args.KvPairSources = rebaseKvPairSources(dir, args.KvPairSources)
+		k.SecretGenerator[i] = args
+	}
+	k.PatchesStrategicMerge = make([]types.PatchStrategicMerge, len(kt.kustomization.PatchesStrategicMerge))
+	for i, p := range kt.kustomization.PatchesStrategicMerge {
+		if _, err := kt.ldr.Load(string(p)); err == nil {
+			p = types.PatchStrategicMerge(rebase(dir, string(p)))
+		}
+		k.PatchesStrategicMerge[i] = p
+	}
+	k.PatchesJson6902 = make([]types.Patch, len(kt.kustomization.PatchesJson6902))
+	for i, p := range kt.kustomization.PatchesJson6902 {
+		if p.Path != "" {
+			p.Path = rebase(dir, p.Path)
+		}
+		k.PatchesJson6902[i] = p
+	}
+	k.Replacements = make([]types.ReplacementField, len(kt.kustomization.Replacements))
+	for i, r := range kt.kustomization.Replacements {
+		if r.Path != "" {
+			r.Path = rebase(dir, r.Path)
+		}
+		k.Replacements[i] = r
+	}
+	return &k
+}
+
+func rebaseKvPairSources(dir string, s types.KvPairSources) types.KvPairSources {
+	files := make([]string, len(s.FileSources))
+	for i, source := range s.FileSources {
+		if key, path, found := strings.Cut(source, "="); found {
+			files[i] = key + "=" + rebase(dir, path)
+		} else {
+			files[i] = rebase(dir, source)
+		}
+	}
+	s.FileSources = files
+	envs := make([]string, len(s.EnvSources))
+	for i, path := range s.EnvSources {
+		envs[i] = rebase(dir, path)
+	}
+	s.EnvSources = envs
+	return s
+}
+
+// configRecorder is a builtin plugin stand-in that keeps
+// the config it's given instead of acting on it.
+type configRecorder struct {
+	config []byte
+}
+
+func (r *configRecorder) Config(_ *resmap.PluginHelpers, config []byte) error {
+	r.config = config
+	return nil
+}
+
+func (r *configRecorder) Generate() (resmap.ResMap, error) {
+	return nil, nil
+}
+
+func (r *configRecorder) Transform(resmap.ResMap) error {
+	return nil
+}
+
+// inlineBuiltinConfig turns the config of a builtin plugin into
+// an inline generator or transformer entry. The index of the entry
+// in its list makes its name unique.
+func inlineBuiltinConfig(
+	bpt builtinhelpers.BuiltinPluginType, index int, config []byte) (string, error) {
+	c, err := yaml.Parse(string(config))
+	if err != nil {
+		return "", errors.WrapPrefixf(err, "builtin %s config", bpt)
+	}
+	name := fmt.Sprintf("%s-%d", strings.ToLower(bpt.String()), index)
+	result := yaml.NewMapRNode(nil)
+	result.SetApiVersion(konfig.BuiltinPluginApiVersion)
+	result.SetKind(bpt.String())
+	if err = result.SetName(name); err != nil {
+		return "", errors.Wrap(err)
+	}
+	err = c.VisitFields(func(field *yaml.MapNode) error {
+		key := field.Key.YNode().Value
+		if yaml.IsMissingOrNull(field.Value) {
+			return nil
+		}
+		if key == yaml.MetadataField {
+			return field.Value.VisitFields(func(m *yaml.MapNode) error {
+				return result.PipeE(
+					yaml.Lookup(yaml.MetadataField),
+					yaml.SetField(m.Key.YNode().Value, m.Value))
+			})
+		}
+		// The configurators don't tag all their fields;
+		// the plugins read them case-insensitively.
+		key = strings.ToLower(key[:1]) + key[1:]
+		return result.PipeE(yaml.SetField(key, field.Value))
+	})
+	if err != nil {
+		return "", errors.Wrap(err)
+	}
+	s, err := result.String()
+	if err != nil {
+		return "", errors.Wrap(err)
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// rebasePlugins rebases the paths of a list of generators,
+// transformers or validators, leaving inline configs alone.
+func (kt *KustTarget) rebasePlugins(dir string, entries []string) []string {
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if _, err := kt.rFactory.NewResMapFromBytes([]byte(entry)); err == nil {
+			result = append(result, entry)
+			continue
+		}
+		result = append(result, rebase(dir, entry))
+	}
+	return result
+}
+
+// rebase returns path, relative to dir, as relative to the top root.
+// Remote paths are left alone.
+func rebase(dir, path string) string {
+	if dir == "" || filepath.IsAbs(path) || loader.IsRemoteFile(path) {
+		return path
+	}
+	if _, err := git.NewRepoSpecFromURL(path); err == nil {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -4,20 +4,28 @@
 package target
 
 import (
+	"reflect"
+	"strings"
+
 	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
 )
 
 // multiTransformer contains a list of transformers.
 type multiTransformer struct {
 	transformers []*resmap.TransformerWithProperties
+	// timings, if not nil, receives the time taken by each transformer.
+	timings *types.BuildTimings
 }
 
 var _ resmap.Transformer = &multiTransformer{}
 
 // newMultiTransformer constructs a multiTransformer.
-func newMultiTransformer(t []*resmap.TransformerWithProperties) resmap.Transformer {
+func newMultiTransformer(
+	t []*resmap.TransformerWithProperties, timings *types.BuildTimings) resmap.Transformer {
 	r := &multiTransformer{
 		transformers: make([]*resmap.TransformerWithProperties, len(t)),
+		timings:      timings,
 	}
 	copy(r.transformers, t)
 	return r
@@ -27,7 +35,7 @@ func newMultiTransformer(t []*resmap.TransformerWithProperties) resmap.Transform
 // optionally detecting and erroring on commutation conflict.
 func (o *multiTransformer) Transform(m resmap.ResMap) error {
 	for _, t := range o.transformers {
-		if err := t.Transform(m); err != nil {
+		if err := o.transform(t, m); err != nil {
 			return err
 		}
 		if t.Origin != nil {
@@ -39,3 +47,23 @@ func (o *multiTransformer) Transform(m resmap.ResMap) error {
 	}
 	return nil
 }
+
+func (o *multiTransformer) transform(
+	t *resmap.TransformerWithProperties, m resmap.ResMap) error {
+	if o.timings == nil {
+		return t.Transform(m)
+	}
+	return o.timings.TimeTransformer(transformerName(t.Transformer), func() error {
+		return t.Transform(m)
+	})
+}
+
+// transformerName returns the name of the transformer's type,
+// e.g. NamespaceTransformer for a builtins.NamespaceTransformerPlugin.
+func transformerName(t resmap.Transformer) string {
+	typ := reflect.TypeOf(t)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return strings.TrimSuffix(typ.Name(), "Plugin")
+}
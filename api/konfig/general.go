@@ -44,6 +44,21 @@ const (
 	// An environment variable to turn on/off adding the ManagedByLabelKey
 	EnableManagedbyLabelEnv = "KUSTOMIZE_ENABLE_MANAGEDBY_LABEL"
 
+	// Annotation that marks a tombstone: a resource that a previous
+	// build output and the current build doesn't, for pruning tools.
+	PruneAnnotation = "kustomize.config.k8s.io/prune"
+
+	// Annotation that makes the namespace transformer set the
+	// namespace of a resource that kustomize considers cluster
+	// scoped, when set to "true". It is removed from the output.
+	ForceNamespaceAnnotation = "kustomize.config.k8s.io/force-namespace"
+
+	// Annotation that controls whether a content hash is appended to
+	// the name of a generated resource. Plugins set it to "true" to
+	// request a hash; "false" suppresses the hash even if the
+	// generator options ask for one. It is removed from the output.
+	NeedsHashAnnotation = "kustomize.config.k8s.io/needs-hash"
+
 	// Label key that indicates the resources are validated by a validator
 	ValidatedByLabelKey = "validated-by"
 )
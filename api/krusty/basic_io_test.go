@@ -6,6 +6,8 @@ package krusty_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/kustomize/api/internal/kusterr"
 	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
 )
@@ -110,3 +112,22 @@ spec:
 		t.Fatalf("unexpected error: %q", err)
 	}
 }
+
+// Misspelled top-level fields in the kustomization file are
+// always rejected, so they can't silently produce an empty build.
+func TestBasicIOMisspelledField(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resourses:
+- service.yaml
+`)
+	th.WriteF("service.yaml", `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`)
+	err := th.RunWithErr(".", th.MakeDefaultOptions())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "resourses"`)
+}
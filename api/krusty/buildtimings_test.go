@@ -0,0 +1,80 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestBuildTimings(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("base", `
+resources:
+- deployment.yaml
+namePrefix: base-
+`)
+	th.WriteF("base/deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`)
+	th.WriteK("overlay", `
+resources:
+- ../base
+configMapGenerator:
+- name: config
+  literals:
+  - mode=prod
+labels:
+- pairs:
+    env: prod
+`)
+
+	opts := th.MakeDefaultOptions()
+	opts.CollectTimings = true
+	k := krusty.MakeKustomizer(&opts)
+	_, err := k.Run(th.GetFSys(), "overlay")
+	require.NoError(t, err)
+
+	timings := k.Timings()
+	require.NotNil(t, timings)
+	for _, phase := range types.BuildPhases() {
+		d, ok := timings.Phases[phase]
+		assert.True(t, ok, "missing phase %s", phase)
+		assert.GreaterOrEqual(t, d.Nanoseconds(), int64(0), "phase %s", phase)
+	}
+	var names []string
+	for _, tt := range timings.Transformers {
+		assert.GreaterOrEqual(t, tt.Duration.Nanoseconds(), int64(0))
+		names = append(names, tt.Transformer)
+	}
+	assert.Contains(t, names, "PrefixTransformer")
+	assert.Contains(t, names, "LabelTransformer")
+}
+
+func TestBuildTimingsOff(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resources:
+- deployment.yaml
+`)
+	th.WriteF("deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`)
+	opts := th.MakeDefaultOptions()
+	k := krusty.MakeKustomizer(&opts)
+	_, err := k.Run(th.GetFSys(), ".")
+	require.NoError(t, err)
+	assert.Nil(t, k.Timings())
+}
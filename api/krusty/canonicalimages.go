@@ -0,0 +1,42 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/image"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// canonicalizeImages replaces the images of the workload containers
+// in m with their fully-qualified form, so that equivalent
+// references like nginx and docker.io/library/nginx:latest are
+// output the same way.
+func canonicalizeImages(m resmap.ResMap) error {
+	return m.ApplyFilter(kio.FilterAll(yaml.FilterFunc(
+		func(node *yaml.RNode) (*yaml.RNode, error) {
+			podSpec, err := filtersutil.LookupPodSpec(node)
+			if err != nil || podSpec == nil {
+				return node, err
+			}
+			return node, filtersutil.VisitContainers(podSpec,
+				canonicalizeContainerImage, "containers", "initContainers")
+		})))
+}
+
+func canonicalizeContainerImage(container *yaml.RNode) error {
+	field := container.Field("image")
+	if field.IsNilOrEmpty() {
+		return nil
+	}
+	value := yaml.GetValue(field.Value)
+	if canonical := image.Canonical(value); canonical != value {
+		return errors.Wrap(container.PipeE(
+			yaml.SetField("image", yaml.NewStringRNode(canonical))))
+	}
+	return nil
+}
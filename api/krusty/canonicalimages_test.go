@@ -0,0 +1,76 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestCanonicalizeImages(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resources:
+- deployment.yaml
+`)
+	th.WriteF("deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox:1.36
+      containers:
+      - name: web
+        image: nginx
+      - name: proxy
+        image: gcr.io/project/proxy:v2
+`)
+	opts := th.MakeDefaultOptions()
+
+	// Images are unchanged by default.
+	m := th.Run(".", opts)
+	th.AssertActualEqualsExpected(m, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx
+        name: web
+      - image: gcr.io/project/proxy:v2
+        name: proxy
+      initContainers:
+      - image: busybox:1.36
+        name: init
+`)
+
+	opts.CanonicalizeImages = true
+	m = th.Run(".", opts)
+	th.AssertActualEqualsExpected(m, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - image: docker.io/library/nginx:latest
+        name: web
+      - image: gcr.io/project/proxy:v2
+        name: proxy
+      initContainers:
+      - image: docker.io/library/busybox:1.36
+        name: init
+`)
+}
@@ -6,6 +6,9 @@ package krusty_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/hasher"
 	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
 )
 
@@ -591,3 +594,74 @@ metadata:
   name: test-m8t7bmb6g2
 `)
 }
+
+// The name suffix of a generated ConfigMap or Secret is the
+// hash of the resource without the suffix.
+func TestGeneratorNameSuffixIsResourceHash(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("hashed", `
+configMapGenerator:
+- name: config
+  literals:
+  - b=two
+  - a=one
+secretGenerator:
+- name: creds
+  literals:
+  - password=hunter2
+`)
+	th.WriteK("unhashed", `
+generatorOptions:
+  disableNameSuffixHash: true
+configMapGenerator:
+- name: config
+  literals:
+  - a=one
+  - b=two
+secretGenerator:
+- name: creds
+  literals:
+  - password=hunter2
+`)
+	hashed := th.Run("hashed", th.MakeDefaultOptions()).Resources()
+	unhashed := th.Run("unhashed", th.MakeDefaultOptions()).Resources()
+	require.Len(t, hashed, 2)
+	require.Len(t, unhashed, 2)
+	for i, r := range unhashed {
+		h, err := r.Hash(&hasher.Hasher{})
+		require.NoError(t, err)
+		assert.Equal(t, hashed[i].GetName(), r.GetName()+"-"+h)
+	}
+}
+
+func TestGeneratorNeedsHashAnnotation(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+configMapGenerator:
+- name: hashed
+  literals:
+  - a=one
+- name: fixed
+  literals:
+  - a=one
+  options:
+    annotations:
+      kustomize.config.k8s.io/needs-hash: "false"
+`)
+	m := th.Run(".", th.MakeDefaultOptions())
+	th.AssertActualEqualsExpected(m, `
+apiVersion: v1
+data:
+  a: one
+kind: ConfigMap
+metadata:
+  name: hashed-mdmdh82h52
+---
+apiVersion: v1
+data:
+  a: one
+kind: ConfigMap
+metadata:
+  name: fixed
+`)
+}
@@ -314,3 +314,95 @@ spec:
   location: Arizona
 `)
 }
+
+func TestCustomConfigCommonLabelsForCrd(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+commonLabels:
+  app: shop
+resources:
+- canaries.yaml
+configurations:
+- labels.yaml
+`)
+	th.WriteF("labels.yaml", `
+commonLabels:
+- path: spec/selector/matchLabels
+  group: rollouts.example.com
+  kind: Canary
+  create: true
+- path: spec/analysis/template/metadata/labels
+  group: rollouts.example.com
+  kind: Canary
+`)
+	th.WriteF("canaries.yaml", `
+apiVersion: rollouts.example.com/v1
+kind: Canary
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      tier: web
+  analysis:
+    template:
+      metadata:
+        labels:
+          tier: web
+---
+apiVersion: rollouts.example.com/v1
+kind: Canary
+metadata:
+  name: worker
+spec:
+  replicas: 1
+---
+apiVersion: other.example.com/v1
+kind: Canary
+metadata:
+  name: other
+spec:
+  replicas: 1
+`)
+	m := th.Run(".", th.MakeDefaultOptions())
+	th.AssertActualEqualsExpected(m, `
+apiVersion: rollouts.example.com/v1
+kind: Canary
+metadata:
+  labels:
+    app: shop
+  name: web
+spec:
+  analysis:
+    template:
+      metadata:
+        labels:
+          app: shop
+          tier: web
+  selector:
+    matchLabels:
+      app: shop
+      tier: web
+---
+apiVersion: rollouts.example.com/v1
+kind: Canary
+metadata:
+  labels:
+    app: shop
+  name: worker
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: shop
+---
+apiVersion: other.example.com/v1
+kind: Canary
+metadata:
+  labels:
+    app: shop
+  name: other
+spec:
+  replicas: 1
+`)
+}
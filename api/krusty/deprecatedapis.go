@@ -0,0 +1,104 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+)
+
+type apiVersionKind struct {
+	apiVersion string
+	kind       string
+}
+
+// apiReplacement is what to use instead of a deprecated apiVersion,
+// if anything, and the Kubernetes release that no longer serves it.
+type apiReplacement struct {
+	apiVersion string
+	removedIn  string
+}
+
+// deprecatedAPIs lists the deprecated apiVersions of built-in kinds.
+//
+//nolint:gochecknoglobals
+var deprecatedAPIs = map[apiVersionKind]apiReplacement{
+	{"extensions/v1beta1", "DaemonSet"}:                          {"apps/v1", "1.16"},
+	{"extensions/v1beta1", "Deployment"}:                         {"apps/v1", "1.16"},
+	{"extensions/v1beta1", "ReplicaSet"}:                         {"apps/v1", "1.16"},
+	{"extensions/v1beta1", "NetworkPolicy"}:                      {"networking.k8s.io/v1", "1.16"},
+	{"extensions/v1beta1", "PodSecurityPolicy"}:                  {"", "1.16"},
+	{"policy/v1beta1", "PodSecurityPolicy"}:                      {"", "1.25"},
+	{"extensions/v1beta1", "Ingress"}:                            {"networking.k8s.io/v1", "1.22"},
+	{"apps/v1beta1", "Deployment"}:                               {"apps/v1", "1.16"},
+	{"apps/v1beta1", "StatefulSet"}:                              {"apps/v1", "1.16"},
+	{"apps/v1beta2", "DaemonSet"}:                                {"apps/v1", "1.16"},
+	{"apps/v1beta2", "Deployment"}:                               {"apps/v1", "1.16"},
+	{"apps/v1beta2", "ReplicaSet"}:                               {"apps/v1", "1.16"},
+	{"apps/v1beta2", "StatefulSet"}:                              {"apps/v1", "1.16"},
+	{"networking.k8s.io/v1beta1", "Ingress"}:                     {"networking.k8s.io/v1", "1.22"},
+	{"networking.k8s.io/v1beta1", "IngressClass"}:                {"networking.k8s.io/v1", "1.22"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition"}: {"apiextensions.k8s.io/v1", "1.22"},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration"}: {
+		"admissionregistration.k8s.io/v1", "1.22"},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration"}: {
+		"admissionregistration.k8s.io/v1", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole"}:        {"rbac.authorization.k8s.io/v1", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding"}: {"rbac.authorization.k8s.io/v1", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role"}:               {"rbac.authorization.k8s.io/v1", "1.22"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding"}:        {"rbac.authorization.k8s.io/v1", "1.22"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass"}:              {"scheduling.k8s.io/v1", "1.22"},
+	{"storage.k8s.io/v1beta1", "CSIDriver"}:                     {"storage.k8s.io/v1", "1.22"},
+	{"storage.k8s.io/v1beta1", "CSINode"}:                       {"storage.k8s.io/v1", "1.22"},
+	{"storage.k8s.io/v1beta1", "StorageClass"}:                  {"storage.k8s.io/v1", "1.22"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment"}:              {"storage.k8s.io/v1", "1.22"},
+	{"storage.k8s.io/v1beta1", "CSIStorageCapacity"}:            {"storage.k8s.io/v1", "1.27"},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest"}: {
+		"certificates.k8s.io/v1", "1.22"},
+	{"coordination.k8s.io/v1beta1", "Lease"}:               {"coordination.k8s.io/v1", "1.22"},
+	{"batch/v1beta1", "CronJob"}:                           {"batch/v1", "1.25"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice"}:          {"discovery.k8s.io/v1", "1.25"},
+	{"events.k8s.io/v1beta1", "Event"}:                     {"events.k8s.io/v1", "1.25"},
+	{"policy/v1beta1", "PodDisruptionBudget"}:              {"policy/v1", "1.25"},
+	{"node.k8s.io/v1beta1", "RuntimeClass"}:                {"node.k8s.io/v1", "1.25"},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler"}:     {"autoscaling/v2", "1.25"},
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler"}:     {"autoscaling/v2", "1.26"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema"}: {"flowcontrol.apiserver.k8s.io/v1beta3", "1.26"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration"}: {
+		"flowcontrol.apiserver.k8s.io/v1beta3", "1.26"},
+}
+
+// deprecatedAPIWarnings returns a message for each resource with
+// a deprecated apiVersion.
+func deprecatedAPIWarnings(m resmap.ResMap) []string {
+	var warnings []string
+	for _, r := range m.Resources() {
+		rep, ok := deprecatedAPIs[apiVersionKind{r.GetApiVersion(), r.GetKind()}]
+		if !ok {
+			continue
+		}
+		use := "use " + rep.apiVersion
+		if rep.apiVersion == "" {
+			use = "the kind has no replacement"
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"%s uses deprecated apiVersion %s, removed in Kubernetes %s; %s",
+			displayName(r), r.GetApiVersion(), rep.removedIn, use))
+	}
+	return warnings
+}
+
+// checkDeprecatedAPIs returns a warning for each resource with a
+// deprecated apiVersion, or an error listing them if strict.
+func checkDeprecatedAPIs(m resmap.ResMap, strict bool) ([]string, error) {
+	warnings := deprecatedAPIWarnings(m)
+	if strict && len(warnings) > 0 {
+		return nil, errors.Errorf(
+			"deprecated apiVersions:\n%s", strings.Join(warnings, "\n"))
+	}
+	return warnings, nil
+}
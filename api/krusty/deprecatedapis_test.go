@@ -0,0 +1,87 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func writeDeploymentWithAPIVersion(th kusttest_test.Harness, apiVersion string) {
+	th.WriteK(".", `
+resources:
+- deployment.yaml
+`)
+	th.WriteF("deployment.yaml", `
+apiVersion: `+apiVersion+`
+kind: Deployment
+metadata:
+  name: web
+`)
+}
+
+func TestDeprecatedAPIWarning(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	opts := th.MakeDefaultOptions()
+	k := krusty.MakeKustomizer(&opts)
+
+	writeDeploymentWithAPIVersion(th, "extensions/v1beta1")
+	_, err := k.Run(th.GetFSys(), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Deployment web uses deprecated apiVersion extensions/v1beta1, " +
+			"removed in Kubernetes 1.16; use apps/v1",
+	}, k.Warnings())
+
+	writeDeploymentWithAPIVersion(th, "apps/v1")
+	_, err = k.Run(th.GetFSys(), ".")
+	require.NoError(t, err)
+	assert.Empty(t, k.Warnings())
+}
+
+func TestDeprecatedAPIWithoutReplacement(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resources:
+- psp.yaml
+`)
+	th.WriteF("psp.yaml", `
+apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: restricted
+`)
+	opts := th.MakeDefaultOptions()
+	k := krusty.MakeKustomizer(&opts)
+	_, err := k.Run(th.GetFSys(), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"PodSecurityPolicy restricted uses deprecated apiVersion policy/v1beta1, " +
+			"removed in Kubernetes 1.25; the kind has no replacement",
+	}, k.Warnings())
+}
+
+func TestDeprecatedAPIStrict(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	opts := th.MakeDefaultOptions()
+	opts.FailOnDeprecatedAPIs = true
+
+	writeDeploymentWithAPIVersion(th, "extensions/v1beta1")
+	err := th.RunWithErr(".", opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deprecated apiVersions:\n"+
+		"Deployment web uses deprecated apiVersion extensions/v1beta1")
+
+	writeDeploymentWithAPIVersion(th, "apps/v1")
+	th.AssertActualEqualsExpected(th.Run(".", opts), `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`)
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+// Generated and transformed maps must be emitted in sorted
+// key order, so that building the same kustomization twice
+// yields byte-identical output.
+func TestBuildOutputIsDeterministic(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("base", `
+resources:
+- deployment.yaml
+- service.yaml
+generatorOptions:
+  labels:
+    zeta: z
+    alpha: a
+    mu: m
+  annotations:
+    zulu: z
+    bravo: b
+configMapGenerator:
+- name: config
+  literals:
+  - zebra=stripes
+  - apple=red
+  - mango=yellow
+  - kiwi=green
+secretGenerator:
+- name: creds
+  literals:
+  - user=admin
+  - password=hunter2
+  - token=abc
+`)
+	th.WriteF("base/deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    tier: backend
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx
+        envFrom:
+        - configMapRef:
+            name: config
+        - secretRef:
+            name: creds
+`)
+	th.WriteF("base/service.yaml", `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+spec:
+  ports:
+  - port: 80
+`)
+	th.WriteK("overlay", `
+resources:
+- ../base
+namePrefix: prod-
+commonLabels:
+  team: web
+  env: prod
+  app: shop
+commonAnnotations:
+  owner: web-team
+  cost-center: "42"
+images:
+- name: nginx
+  newTag: "1.25"
+configMapGenerator:
+- name: config
+  behavior: merge
+  literals:
+  - banana=yellow
+  - cherry=red
+`)
+	first, err := th.Run("overlay", th.MakeDefaultOptions()).AsYaml()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := th.Run("overlay", th.MakeDefaultOptions()).AsYaml()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("build %d differs from the first\n--- first\n%s\n--- got\n%s\n",
+				i+2, first, again)
+		}
+	}
+	th.AssertActualEqualsExpected(th.Run("overlay", th.MakeDefaultOptions()), `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    cost-center: "42"
+    owner: web-team
+  labels:
+    app: shop
+    env: prod
+    team: web
+    tier: backend
+  name: prod-app
+spec:
+  selector:
+    matchLabels:
+      app: shop
+      env: prod
+      team: web
+  template:
+    metadata:
+      annotations:
+        cost-center: "42"
+        owner: web-team
+      labels:
+        app: shop
+        env: prod
+        team: web
+    spec:
+      containers:
+      - envFrom:
+        - configMapRef:
+            name: prod-config-4m7hddb644
+        - secretRef:
+            name: prod-creds-m9m522h6d8
+        image: nginx:1.25
+        name: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    cost-center: "42"
+    owner: web-team
+  labels:
+    app: shop
+    env: prod
+    team: web
+  name: prod-app
+spec:
+  ports:
+  - port: 80
+  selector:
+    app: shop
+    env: prod
+    team: web
+---
+apiVersion: v1
+data:
+  apple: red
+  banana: yellow
+  cherry: red
+  kiwi: green
+  mango: yellow
+  zebra: stripes
+kind: ConfigMap
+metadata:
+  annotations:
+    bravo: b
+    cost-center: "42"
+    owner: web-team
+    zulu: z
+  labels:
+    alpha: a
+    app: shop
+    env: prod
+    mu: m
+    team: web
+    zeta: z
+  name: prod-config-4m7hddb644
+---
+apiVersion: v1
+data:
+  password: aHVudGVyMg==
+  token: YWJj
+  user: YWRtaW4=
+kind: Secret
+metadata:
+  annotations:
+    bravo: b
+    cost-center: "42"
+    owner: web-team
+    zulu: z
+  labels:
+    alpha: a
+    app: shop
+    env: prod
+    mu: m
+    team: web
+    zeta: z
+  name: prod-creds-m9m522h6d8
+type: Opaque
+`)
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestEffectiveLabels(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("base", `
+resources:
+- resources.yaml
+`)
+	th.WriteF("base/resources.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: web-pod
+        track: stable
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    app: web
+`)
+	th.WriteK("overlay", `
+resources:
+- ../base
+labels:
+- pairs:
+    team: storefront
+`)
+	m := th.Run("overlay", th.MakeDefaultOptions())
+	require.Equal(t, 2, m.Size())
+	dep := m.Resources()[0]
+	assert.Equal(t, map[string]string{
+		"app":   "web",
+		"team":  "storefront",
+		"track": "stable",
+	}, dep.EffectiveLabels())
+	cm := m.Resources()[1]
+	assert.Equal(t, map[string]string{
+		"app":  "web",
+		"team": "storefront",
+	}, cm.EffectiveLabels())
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+// Fingerprint returns the hex form of a sha256 over the output of
+// a build, e.g. the ResMap returned by Kustomizer.Run, so that CI
+// can skip applying a build whose fingerprint hasn't changed. It
+// changes whenever a resource is added, removed or changed, other
+// than in kustomize's internal annotations, but not when the order
+// of the resources or of their fields changes.
+func Fingerprint(m resmap.ResMap) (string, error) {
+	hashes := make([]string, 0, m.Size())
+	for _, r := range m.Resources() {
+		h, err := r.ContentHash()
+		if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, r.CurId().String()+" "+h)
+	}
+	sort.Strings(hashes)
+	sum := sha256.New()
+	for _, h := range hashes {
+		fmt.Fprintln(sum, h)
+	}
+	return fmt.Sprintf("%x", sum.Sum(nil)), nil
+}
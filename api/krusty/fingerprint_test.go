@@ -0,0 +1,82 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func fingerprint(t *testing.T, th kusttest_test.Harness) string {
+	t.Helper()
+	f, err := krusty.Fingerprint(th.Run(".", th.MakeDefaultOptions()))
+	require.NoError(t, err)
+	return f
+}
+
+func TestFingerprint(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+namePrefix: p-
+resources:
+- deployment.yaml
+- service.yaml
+configMapGenerator:
+- name: config
+  literals:
+  - mode=prod
+`)
+	th.WriteF("deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+`)
+	th.WriteF("service.yaml", `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`)
+	f := fingerprint(t, th)
+	assert.Len(t, f, 64)
+	assert.Equal(t, f, fingerprint(t, th))
+
+	// Changing the order of resources and fields doesn't matter.
+	th.WriteK(".", `
+namePrefix: p-
+configMapGenerator:
+- name: config
+  literals:
+  - mode=prod
+resources:
+- service.yaml
+- deployment.yaml
+`)
+	th.WriteF("deployment.yaml", `
+kind: Deployment
+apiVersion: apps/v1
+spec:
+  replicas: 2
+metadata:
+  name: web
+`)
+	assert.Equal(t, f, fingerprint(t, th))
+
+	th.WriteF("deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+`)
+	assert.NotEqual(t, f, fingerprint(t, th))
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestFlatten(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("base", `
+resources:
+- deployment.yaml
+transformers:
+- |-
+  apiVersion: builtin
+  kind: NamespaceTransformer
+  metadata:
+    name: ns
+    namespace: app
+`)
+	th.WriteF("base/deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`)
+	th.WriteC("components/monitoring", `
+resources:
+- servicemonitor.yaml
+transformers:
+- labels.yaml
+`)
+	th.WriteF("components/monitoring/servicemonitor.yaml", `
+apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: app
+`)
+	th.WriteF("components/monitoring/labels.yaml", `
+apiVersion: builtin
+kind: LabelTransformer
+metadata:
+  name: labels
+labels:
+  monitored: "true"
+fieldSpecs:
+- path: metadata/labels
+  create: true
+`)
+	th.WriteC("components/debug", `
+resources:
+- debug.yaml
+transformers:
+- annotations.yaml
+patches:
+- path: verbose.yaml
+`)
+	th.WriteF("components/debug/debug.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: debug
+`)
+	th.WriteF("components/debug/annotations.yaml", `
+apiVersion: builtin
+kind: AnnotationsTransformer
+metadata:
+  name: annotations
+annotations:
+  debug: "true"
+fieldSpecs:
+- path: metadata/annotations
+  create: true
+`)
+	th.WriteF("components/debug/verbose.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`)
+	th.WriteC("components/config", `
+configMapGenerator:
+- name: app
+  files:
+  - app.properties
+  - log=logging/log.properties
+images:
+- name: nginx
+  newTag: "1.25"
+`)
+	th.WriteF("components/config/app.properties", "port=8080\n")
+	th.WriteF("components/config/logging/log.properties", "level=debug\n")
+	th.WriteK("overlay", `
+resources:
+- ../base
+- service.yaml
+components:
+- ../components/monitoring
+- ../components/debug
+- ../components/config
+`)
+	th.WriteF("overlay/service.yaml", `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`)
+
+	opts := th.MakeDefaultOptions()
+	k, err := krusty.MakeKustomizer(&opts).Flatten(th.GetFSys(), "overlay")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"../base/deployment.yaml",
+		"service.yaml",
+		"../components/monitoring/servicemonitor.yaml",
+		"../components/debug/debug.yaml",
+	}, k.Resources)
+	assert.Equal(t, []string{
+		`apiVersion: builtin
+kind: NamespaceTransformer
+metadata:
+  name: ns
+  namespace: app`,
+		"../components/monitoring/labels.yaml",
+		"../components/debug/annotations.yaml",
+		`apiVersion: builtin
+kind: ImageTagTransformer
+metadata:
+  name: imagetagtransformer-3
+fieldSpecs:
+- create: true
+  path: spec/containers[]/image
+- create: true
+  path: spec/initContainers[]/image
+- create: true
+  path: spec/template/spec/containers[]/image
+- create: true
+  path: spec/template/spec/initContainers[]/image
+imageTag:
+  name: nginx
+  newTag: "1.25"`,
+	}, k.Transformers)
+	assert.Equal(t, []string{
+		`apiVersion: builtin
+kind: ConfigMapGenerator
+metadata:
+  name: configmapgenerator-0
+files:
+- ../components/config/app.properties
+- log=../components/config/logging/log.properties
+name: app`,
+	}, k.Generators)
+	assert.Equal(t, []types.Patch{
+		{Path: "../components/debug/verbose.yaml"},
+	}, k.Patches)
+	assert.Equal(t, types.KustomizationKind, k.Kind)
+}
+
+func TestFlattenUnsupportedField(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("app", `
+resources:
+- deployment.yaml
+vars:
+- name: APP
+  objref:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: app
+`)
+	th.WriteF("app/deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`)
+
+	opts := th.MakeDefaultOptions()
+	_, err := krusty.MakeKustomizer(&opts).Flatten(th.GetFSys(), "app")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vars")
+}
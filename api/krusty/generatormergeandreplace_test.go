@@ -748,3 +748,48 @@ secretGenerator:
 		t.Fatalf("unexpected error %v", err)
 	}
 }
+
+func TestSecretGeneratorMergeKeepsBaseKeys(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK("base", `
+resources:
+- secret.yaml
+`)
+	th.WriteF("base/secret.yaml", `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+type: Opaque
+data:
+  username: YWRtaW4=
+  password: YmFzZQ==
+stringData:
+  host: db.example.com
+  port: "5432"
+`)
+	th.WriteK("overlay", `
+resources:
+- ../base
+secretGenerator:
+- name: creds
+  behavior: merge
+  literals:
+  - password=overlay
+  - host=replica.example.com
+`)
+	m := th.Run("overlay", th.MakeDefaultOptions())
+	th.AssertActualEqualsExpected(m, `
+apiVersion: v1
+data:
+  host: cmVwbGljYS5leGFtcGxlLmNvbQ==
+  password: b3ZlcmxheQ==
+  username: YWRtaW4=
+kind: Secret
+metadata:
+  name: creds
+stringData:
+  port: "5432"
+type: Opaque
+`)
+}
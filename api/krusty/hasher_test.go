@@ -0,0 +1,107 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// sha256Hasher hashes the data of a resource with SHA-256.
+type sha256Hasher struct{}
+
+func (sha256Hasher) Hash(node *yaml.RNode) (string, error) {
+	data, err := node.Pipe(yaml.Lookup("data"))
+	if err != nil {
+		return "", err
+	}
+	s, err := data.String()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))[:10], nil
+}
+
+// fixedHasher returns the same hash for every resource.
+type fixedHasher string
+
+func (h fixedHasher) Hash(*yaml.RNode) (string, error) {
+	return string(h), nil
+}
+
+func writeHasherTestKustomization(th kusttest_test.Harness) {
+	th.WriteK(".", `
+configMapGenerator:
+- name: config
+  literals:
+  - mode=prod
+resources:
+- deployment.yaml
+`)
+	th.WriteF("deployment.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      volumes:
+      - name: config
+        configMap:
+          name: config
+`)
+}
+
+func TestDefaultHasher(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeHasherTestKustomization(th)
+	m := th.Run(".", th.MakeDefaultOptions())
+	th.AssertActualEqualsExpected(m, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      volumes:
+      - configMap:
+          name: config-mgc92dd9c6
+        name: config
+---
+apiVersion: v1
+data:
+  mode: prod
+kind: ConfigMap
+metadata:
+  name: config-mgc92dd9c6
+`)
+}
+
+func TestCustomHasher(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeHasherTestKustomization(th)
+	opts := th.MakeDefaultOptions()
+	opts.Hasher = sha256Hasher{}
+	m := th.Run(".", opts)
+	expected, err := sha256Hasher{}.Hash(yaml.MustParse("data:\n  mode: prod\n"))
+	require.NoError(t, err)
+	name, err := m.Resources()[0].GetString(
+		"spec.template.spec.volumes[0].configMap.name")
+	require.NoError(t, err)
+	assert.Equal(t, "config-"+expected, name)
+	assert.Equal(t, name, m.Resources()[1].GetName())
+
+	opts.Hasher = fixedHasher("Not_A_Label")
+	err = th.RunWithErr(".", opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `hash "Not_A_Label" of ConfigMap.v1.[noGrp]/config.[noNs] is not a valid name suffix`)
+}
@@ -6,7 +6,9 @@ package krusty
 import (
 	"fmt"
 	"log"
+	"time"
 
+	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/internal/builtins"
 	pLdr "sigs.k8s.io/kustomize/api/internal/plugins/loader"
 	"sigs.k8s.io/kustomize/api/internal/target"
@@ -32,13 +34,19 @@ import (
 type Kustomizer struct {
 	options     *Options
 	depProvider *provider.DepProvider
+	timings     *types.BuildTimings
+	warnings    []string
 }
 
 // MakeKustomizer returns an instance of Kustomizer.
 func MakeKustomizer(o *Options) *Kustomizer {
+	depProvider := provider.NewDepProvider()
+	if o.Hasher != nil {
+		depProvider = provider.NewDepProviderWithHasher(o.Hasher)
+	}
 	return &Kustomizer{
 		options:     o,
-		depProvider: provider.NewDepProvider(),
+		depProvider: depProvider,
 	}
 }
 
@@ -54,26 +62,20 @@ func MakeKustomizer(o *Options) *Kustomizer {
 // and Run can be called on each of them).
 func (b *Kustomizer) Run(
 	fSys filesys.FileSystem, path string) (resmap.ResMap, error) {
-	resmapFactory := resmap.NewFactory(b.depProvider.GetResourceFactory())
-	lr := fLdr.RestrictionNone
-	if b.options.LoadRestrictions == types.LoadRestrictionsRootOnly {
-		lr = fLdr.RestrictionRootOnly
+	b.timings = nil
+	b.warnings = nil
+	if b.options.CollectTimings {
+		b.timings = types.NewBuildTimings()
 	}
-	ldr, err := fLdr.NewLoader(lr, path, fSys)
+	start := time.Now()
+	kt, ldr, err := b.loadTarget(fSys, path)
 	if err != nil {
 		return nil, err
 	}
 	defer ldr.Cleanup()
-	kt := target.NewKustTarget(
-		ldr,
-		b.depProvider.GetFieldValidator(),
-		resmapFactory,
-		// The plugin configs are always located on disk, regardless of the fSys passed in
-		pLdr.NewLoader(b.options.PluginConfig, resmapFactory, filesys.MakeFsOnDisk()),
-	)
-	err = kt.Load()
-	if err != nil {
-		return nil, err
+	if b.timings != nil {
+		b.timings.Phases[types.BuildPhaseLoad] += time.Since(start)
+		kt.SetTimings(b.timings)
 	}
 	var bytes []byte
 	if openApiPath, exists := kt.Kustomization().OpenAPI["path"]; exists {
@@ -91,6 +93,30 @@ func (b *Kustomizer) Run(
 	if err != nil {
 		return nil, err
 	}
+	if b.options.FailOnServiceSelectorOverlap {
+		if err = validateServiceSelectors(m); err != nil {
+			return nil, err
+		}
+	}
+	if b.options.ResourceBudget != nil {
+		if err = validateResourceBudget(m, b.options.ResourceBudget); err != nil {
+			return nil, err
+		}
+	}
+	if b.options.UniqueKey != "" {
+		if err = validateUniqueKey(m, b.options.UniqueKey); err != nil {
+			return nil, err
+		}
+	}
+	if b.options.FailOnLongNames {
+		if err = validateNameLengths(m); err != nil {
+			return nil, err
+		}
+	}
+	if b.warnings, err = checkDeprecatedAPIs(m, b.options.FailOnDeprecatedAPIs); err != nil {
+		return nil, err
+	}
+	start = time.Now()
 	err = b.applySortOrder(m, kt)
 	if err != nil {
 		return nil, err
@@ -123,9 +149,97 @@ func (b *Kustomizer) Run(
 			return nil, errors.WrapPrefixf(err, "failed to clean up transformer annotations")
 		}
 	}
+	if b.options.CanonicalizeImages {
+		if err = canonicalizeImages(m); err != nil {
+			return nil, err
+		}
+	}
+	if b.options.PreviousBuild != nil {
+		if err = b.appendTombstones(m, b.options.PreviousBuild); err != nil {
+			return nil, err
+		}
+	}
+	if b.options.OutputFilter != nil {
+		if err = filterOutput(m, *b.options.OutputFilter); err != nil {
+			return nil, err
+		}
+	}
+	if b.timings != nil {
+		b.timings.Phases[types.BuildPhaseEmit] += time.Since(start)
+	}
 	return m, nil
 }
 
+// Timings returns the time taken by the phases of the last Run,
+// and by each transformer it ran, if Options.CollectTimings is set.
+func (b *Kustomizer) Timings() *types.BuildTimings {
+	return b.timings
+}
+
+// Warnings returns the warnings of the last Run, e.g. about
+// resources with a deprecated apiVersion, for the caller to report.
+func (b *Kustomizer) Warnings() []string {
+	return b.warnings
+}
+
+// Flatten returns a single kustomization listing the resource files,
+// generators, transformers, validators and patches of the
+// kustomization at the given path and of all the bases and
+// components it includes. It shows the effective configuration of
+// the kustomization, for debugging; building the result doesn't in
+// general give the same output as Run.
+func (b *Kustomizer) Flatten(
+	fSys filesys.FileSystem, path string) (*types.Kustomization, error) {
+	kt, ldr, err := b.loadTarget(fSys, path)
+	if err != nil {
+		return nil, err
+	}
+	defer ldr.Cleanup()
+	return kt.Flatten()
+}
+
+// loadTarget loads the kustomization at the given path.
+// The caller must clean up the returned loader.
+func (b *Kustomizer) loadTarget(
+	fSys filesys.FileSystem, path string) (*target.KustTarget, ifc.Loader, error) {
+	resmapFactory := resmap.NewFactory(b.depProvider.GetResourceFactory())
+	lr := fLdr.RestrictionNone
+	if b.options.LoadRestrictions == types.LoadRestrictionsRootOnly {
+		lr = fLdr.RestrictionRootOnly
+	}
+	ldr, err := fLdr.NewLoader(lr, path, fSys)
+	if err != nil {
+		return nil, nil, err
+	}
+	kt := target.NewKustTarget(
+		ldr,
+		b.depProvider.GetFieldValidator(),
+		resmapFactory,
+		// The plugin configs are always located on disk, regardless of the fSys passed in
+		pLdr.NewLoader(b.options.PluginConfig, resmapFactory, filesys.MakeFsOnDisk()),
+	)
+	if err = kt.Load(); err != nil {
+		ldr.Cleanup()
+		return nil, nil, err
+	}
+	return kt, ldr, nil
+}
+
+// filterOutput removes the resources not matching the selector.
+func filterOutput(m resmap.ResMap, selector types.Selector) error {
+	selected, err := m.Select(selector)
+	if err != nil {
+		return errors.WrapPrefixf(err, "unable to apply output filter")
+	}
+	m.Clear()
+	for _, r := range selected {
+		if err = m.Append(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *Kustomizer) applySortOrder(m resmap.ResMap, kt *target.KustTarget) error {
 	// Sort order can be defined in two places:
 	// - (new) kustomization file
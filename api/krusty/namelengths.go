@@ -0,0 +1,52 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+)
+
+// maxNameLengths holds the safe name lengths of kinds whose names
+// are limited by more than the 253 characters of a DNS subdomain.
+var maxNameLengths = map[string]int{
+	// The name is a DNS label.
+	"Namespace": 63,
+	"Service":   63,
+	// The name is the value of the job-name label of the pods.
+	"Job": 63,
+	// A Job is named after the CronJob plus an 11 character suffix.
+	"CronJob": 52,
+	// The controller-revision-hash label of the pods is the name
+	// plus an 11 character suffix.
+	"StatefulSet": 52,
+	"DaemonSet":   52,
+}
+
+const defaultMaxNameLength = 253
+
+// validateNameLengths returns an error if the name of a resource,
+// including any prefixes and suffixes, is longer than its kind allows.
+func validateNameLengths(m resmap.ResMap) error {
+	var problems []string
+	for _, r := range m.Resources() {
+		limit, ok := maxNameLengths[r.GetKind()]
+		if !ok {
+			limit = defaultMaxNameLength
+		}
+		if n := len(r.GetName()); n > limit {
+			problems = append(problems, fmt.Sprintf(
+				"name of %s is %d characters long; the limit for a %s is %d",
+				displayName(r), n, r.GetKind(), limit))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(
+			"names too long:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
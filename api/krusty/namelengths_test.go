@@ -0,0 +1,59 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func writeNameLengthTestResources(th kusttest_test.Harness, serviceName string) {
+	th.WriteK(".", `
+namePrefix: storefront-
+nameSuffix: -canary
+namespace: shop
+resources:
+- resources.yaml
+`)
+	th.WriteF("resources.yaml", `
+apiVersion: v1
+kind: Service
+metadata:
+  name: `+serviceName+`
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: `+strings.Repeat("c", 60)+`
+`)
+}
+
+func TestNameLengthTooLong(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeNameLengthTestResources(th, strings.Repeat("s", 52))
+	opts := th.MakeDefaultOptions()
+
+	// The check is off by default.
+	th.Run(".", opts)
+
+	opts.FailOnLongNames = true
+	err := th.RunWithErr(".", opts)
+	require.Error(t, err)
+	assert.Equal(t, `names too long:
+name of Service shop/storefront-`+strings.Repeat("s", 52)+
+		`-canary is 70 characters long; the limit for a Service is 63`,
+		err.Error())
+}
+
+func TestNameLengthSafe(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeNameLengthTestResources(th, "web")
+	opts := th.MakeDefaultOptions()
+	opts.FailOnLongNames = true
+	th.Run(".", opts)
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/kustomize/api/konfig"
 	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
 )
 
@@ -801,3 +803,33 @@ metadata:
   namespace: iter8-monitoring
 `)
 }
+
+func TestForceNamespaceAnnotationIsRemoved(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+namespace: apps
+resources:
+- clusterrole.yaml
+`)
+	th.WriteF("clusterrole.yaml", `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+  annotations:
+    kustomize.config.k8s.io/force-namespace: "true"
+`)
+
+	m := th.Run(".", th.MakeDefaultOptions())
+	for _, r := range m.Resources() {
+		_, found := r.GetAnnotation(konfig.ForceNamespaceAnnotation)
+		assert.False(t, found, r.CurId().String())
+	}
+	th.AssertActualEqualsExpected(m, `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+  namespace: apps
+`)
+}
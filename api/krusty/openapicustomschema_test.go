@@ -642,3 +642,58 @@ spec:
 		assert.Equal(t, kubernetesapi.DefaultOpenAPI, openapi.GetSchemaVersion())
 	})
 }
+
+func TestCustomOpenApiFieldClusterScopedKind(t *testing.T) {
+	runOpenApiTest(t, func(t *testing.T) {
+		t.Helper()
+		th := kusttest_test.MakeHarness(t)
+		fleet := `
+apiVersion: example.com/v1
+kind: Fleet
+metadata:
+  name: fleet
+`
+		th.WriteF("scoped/fleet_schema.json", `
+{
+  "definitions": {},
+  "paths": {
+    "/apis/example.com/v1/fleets/{name}": {
+      "get": {
+        "x-kubernetes-group-version-kind": {
+          "group": "example.com",
+          "kind": "Fleet",
+          "version": "v1"
+        }
+      }
+    }
+  }
+}
+`)
+		th.WriteK("scoped", `
+namespace: apps
+openapi:
+  path: fleet_schema.json
+resources:
+- fleet.yaml
+`)
+		th.WriteF("scoped/fleet.yaml", fleet)
+		th.WriteK("unscoped", `
+namespace: apps
+resources:
+- fleet.yaml
+`)
+		th.WriteF("unscoped/fleet.yaml", fleet)
+
+		// The schema makes Fleet cluster scoped only in its own build.
+		m := th.Run("scoped", th.MakeDefaultOptions())
+		th.AssertActualEqualsExpected(m, fleet)
+		m = th.Run("unscoped", th.MakeDefaultOptions())
+		th.AssertActualEqualsExpected(m, `
+apiVersion: example.com/v1
+kind: Fleet
+metadata:
+  name: fleet
+  namespace: apps
+`)
+	})
+}
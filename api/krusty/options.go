@@ -4,6 +4,7 @@
 package krusty
 
 import (
+	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/internal/plugins/builtinhelpers"
 	"sigs.k8s.io/kustomize/api/types"
 )
@@ -39,6 +40,60 @@ type Options struct {
 	// is added to all the resources in the build out.
 	AddManagedbyLabel bool
 
+	// When true, the build fails if the selector of a Service
+	// matches the pod template labels of more than one workload.
+	FailOnServiceSelectorOverlap bool
+
+	// When true, the build fails if a resource has a deprecated
+	// apiVersion, instead of returning a warning from
+	// Kustomizer.Warnings.
+	FailOnDeprecatedAPIs bool
+
+	// When set, the build fails if two resources have the same
+	// value at this field path, e.g.
+	// metadata.annotations.[example.com/id].
+	UniqueKey string
+
+	// When true, the build fails if the name of a resource,
+	// including prefixes and suffixes, is longer than is safe for
+	// its kind, e.g. 63 characters for a Service.
+	FailOnLongNames bool
+
+	// When true, Run records the time taken by each phase of the
+	// build and by each transformer, reported by Kustomizer.Timings.
+	CollectTimings bool
+
+	// When true, the images of workload containers are replaced
+	// with their fully-qualified form, e.g. nginx is output as
+	// docker.io/library/nginx:latest.
+	CanonicalizeImages bool
+
+	// When set to the output of a previous build, a tombstone is
+	// output for each resource of the previous build that this
+	// build doesn't output, so that pruning tools can delete it.
+	// A tombstone has only the id of the resource and the
+	// annotation
+	//     kustomize.config.k8s.io/prune: "true"
+	PreviousBuild []byte
+
+	// When set, RunSplit groups the output by the value of this
+	// label, e.g. a tenant label.
+	SplitByLabel string
+
+	// When set, only the resources matching this selector are
+	// output. It is applied after all the transformations, so
+	// references to other resources are still resolved.
+	OutputFilter *types.Selector
+
+	// When set, the build fails if the workloads request more
+	// CPU or memory in total than the budget allows.
+	ResourceBudget *ResourceBudget
+
+	// When set, computes the hash appended to the names of generated
+	// ConfigMaps and Secrets, instead of the default hasher.Hasher.
+	// The hash must be a valid DNS label, e.g. lowercase hex.
+	Hasher ifc.KustHasher
+
 	// Restrictions on what can be loaded from the file system.
 	// See type definition.
 	LoadRestrictions types.LoadRestrictions
@@ -0,0 +1,86 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+func TestOutputFilter(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+namePrefix: shop-
+resources:
+- resources.yaml
+`)
+	th.WriteF("resources.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web
+        envFrom:
+        - configMapRef:
+            name: config
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    tier: frontend
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: db
+`)
+	opts := th.MakeDefaultOptions()
+	opts.OutputFilter = &types.Selector{ResId: resid.ResId{Gvk: resid.Gvk{Kind: "Service"}}}
+	th.AssertActualEqualsExpected(th.Run(".", opts), `
+apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    tier: frontend
+  name: shop-web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: shop-db
+`)
+
+	// References are resolved against the whole build.
+	opts.OutputFilter = &types.Selector{ResId: resid.ResId{Gvk: resid.Gvk{Kind: "Deployment"}}}
+	th.AssertActualEqualsExpected(th.Run(".", opts), `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: shop-web
+spec:
+  template:
+    spec:
+      containers:
+      - envFrom:
+        - configMapRef:
+            name: shop-config
+        image: web
+        name: web
+`)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestBuildPreservingComments(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resources:
+- deployment.yaml
+namePrefix: prod-
+images:
+- name: nginx
+  newTag: "1.25"
+`)
+	th.WriteF("deployment.yaml", `
+# The web frontend.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web # renamed by namePrefix
+spec:
+  replicas: 3 # sized for peak traffic
+  template:
+    spec:
+      containers:
+      - name: web # must match the probe config
+        image: nginx:1.23 # bumped by images
+`)
+	m := th.Run(".", th.MakeDefaultOptions())
+	yml, err := m.AsYamlPreservingComments()
+	require.NoError(t, err)
+	assert.Equal(t, `# The web frontend.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: prod-web
+spec:
+  replicas: 3 # sized for peak traffic
+  template:
+    spec:
+      containers:
+      - name: web # must match the probe config
+        image: nginx:1.25
+`, string(yml))
+}
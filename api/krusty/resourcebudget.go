@@ -0,0 +1,122 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// ResourceBudget limits the CPU and memory the workloads of a
+// build may request in total. Limits are Kubernetes quantities,
+// e.g. "4" or "500m" for CPU and "8Gi" for memory; an empty limit
+// isn't checked.
+type ResourceBudget struct {
+	CPU    string
+	Memory string
+}
+
+// validateResourceBudget returns an error if the total CPU or
+// memory requested by the containers of the workloads, times
+// their replicas, exceeds the budget.
+func validateResourceBudget(m resmap.ResMap, budget *ResourceBudget) error {
+	cpu, memory := new(big.Rat), new(big.Rat)
+	for _, r := range m.Resources() {
+		if !r.IsWorkload() {
+			continue
+		}
+		podCPU, podMemory, err := podRequests(r)
+		if err != nil {
+			return err
+		}
+		replicas, err := workloadReplicas(r)
+		if err != nil {
+			return err
+		}
+		cpu.Add(cpu, podCPU.Mul(podCPU, replicas))
+		memory.Add(memory, podMemory.Mul(podMemory, replicas))
+	}
+	var problems []string
+	for _, c := range []struct {
+		name, limit string
+		total       *big.Rat
+		suffixes    []string
+	}{
+		{"cpu", budget.CPU, cpu, []string{""}},
+		{"memory", budget.Memory, memory, []string{"Ei", "Pi", "Ti", "Gi", "Mi", "Ki", ""}},
+	} {
+		if c.limit == "" {
+			continue
+		}
+		limit, err := filtersutil.ParseQuantity(c.limit)
+		if err != nil {
+			return errors.WrapPrefixf(err, "invalid %s budget", c.name)
+		}
+		if c.total.Cmp(limit) > 0 {
+			problems = append(problems, fmt.Sprintf(
+				"requested %s %s exceeds budget %s",
+				c.name, filtersutil.FormatQuantity(c.total, c.suffixes), c.limit))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(
+			"resource budget exceeded:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// podRequests returns the CPU and memory requested by the
+// containers of a workload's pod template.
+func podRequests(r *resource.Resource) (cpu, memory *big.Rat, err error) {
+	cpu, memory = new(big.Rat), new(big.Rat)
+	podSpec, err := r.Pipe(yaml.Lookup(filtersutil.PodSpecPath(r.GetKind())...))
+	if err != nil || podSpec == nil {
+		return cpu, memory, errors.Wrap(err)
+	}
+	err = filtersutil.VisitContainers(podSpec, func(container *yaml.RNode) error {
+		requests, err := container.Pipe(yaml.Lookup("resources", "requests"))
+		if err != nil || requests == nil {
+			return errors.Wrap(err)
+		}
+		for name, total := range map[string]*big.Rat{"cpu": cpu, "memory": memory} {
+			field := requests.Field(name)
+			if field.IsNilOrEmpty() {
+				continue
+			}
+			q, err := filtersutil.ParseQuantity(yaml.GetValue(field.Value))
+			if err != nil {
+				return errors.WrapPrefixf(err,
+					"invalid %s request in %s", name, displayName(r))
+			}
+			total.Add(total, q)
+		}
+		return nil
+	}, "containers")
+	return cpu, memory, err
+}
+
+// workloadReplicas returns the replicas of a workload, or 1 if
+// it has none, e.g. a DaemonSet.
+func workloadReplicas(r *resource.Resource) (*big.Rat, error) {
+	field := r.Field("spec")
+	if field.IsNilOrEmpty() {
+		return big.NewRat(1, 1), nil
+	}
+	replicas := field.Value.Field("replicas")
+	if replicas.IsNilOrEmpty() {
+		return big.NewRat(1, 1), nil
+	}
+	n, ok := new(big.Rat).SetString(yaml.GetValue(replicas.Value))
+	if !ok {
+		return nil, errors.Errorf("invalid replicas in %s", displayName(r))
+	}
+	return n, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func writeBudgetTestResources(th kusttest_test.Harness) {
+	th.WriteK(".", `
+resources:
+- resources.yaml
+`)
+	// 3 * (250m + 500m) + 1 * 1500m = 3750m cpu
+	// 3 * (256Mi + 128Mi) + 1 * 1Gi = 2176Mi memory
+	th.WriteF("resources.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        resources:
+          requests:
+            cpu: 250m
+            memory: 256Mi
+      - name: proxy
+        resources:
+          requests:
+            cpu: "0.5"
+            memory: 128Mi
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      containers:
+      - name: agent
+        resources:
+          requests:
+            cpu: 1500m
+            memory: 1Gi
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`)
+}
+
+func TestResourceBudgetUnder(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeBudgetTestResources(th)
+	opts := th.MakeDefaultOptions()
+	opts.ResourceBudget = &krusty.ResourceBudget{CPU: "4", Memory: "2176Mi"}
+	m := th.Run(".", opts)
+	assert.Equal(t, 3, m.Size())
+}
+
+func TestResourceBudgetOver(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeBudgetTestResources(th)
+	opts := th.MakeDefaultOptions()
+	opts.ResourceBudget = &krusty.ResourceBudget{CPU: "3", Memory: "2Gi"}
+	err := th.RunWithErr(".", opts)
+	require.Error(t, err)
+	assert.Equal(t, "resource budget exceeded:\n"+
+		"requested cpu 3750m exceeds budget 3\n"+
+		"requested memory 2176Mi exceeds budget 2Gi", err.Error())
+}
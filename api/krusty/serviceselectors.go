@@ -0,0 +1,103 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// validateServiceSelectors returns an error if the selector of a
+// Service matches the pod template labels of more than one workload
+// in the Service's namespace.
+func validateServiceSelectors(m resmap.ResMap) error {
+	var workloads []*resource.Resource
+	for _, r := range m.Resources() {
+		if r.IsWorkload() {
+			workloads = append(workloads, r)
+		}
+	}
+	var problems []string
+	for _, svc := range m.Resources() {
+		if svc.GetKind() != "Service" {
+			continue
+		}
+		selector, err := serviceSelector(svc)
+		if err != nil {
+			return err
+		}
+		if len(selector) == 0 {
+			continue
+		}
+		var matches []string
+		for _, w := range workloads {
+			if w.GetNamespace() != svc.GetNamespace() {
+				continue
+			}
+			labels, err := podTemplateLabels(w)
+			if err != nil {
+				return err
+			}
+			if labelsMatch(selector, labels) {
+				matches = append(matches, displayName(w))
+			}
+		}
+		if len(matches) > 1 {
+			problems = append(problems, fmt.Sprintf(
+				"selector of %s matches pods of %s",
+				displayName(svc), strings.Join(matches, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(
+			"overlapping Service selectors:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+func serviceSelector(svc *resource.Resource) (map[string]string, error) {
+	node, err := svc.Pipe(yaml.Lookup("spec", "selector"))
+	if err != nil || node == nil {
+		return nil, errors.Wrap(err)
+	}
+	selector := make(map[string]string)
+	err = node.VisitFields(func(n *yaml.MapNode) error {
+		selector[n.Key.YNode().Value] = n.Value.YNode().Value
+		return nil
+	})
+	return selector, errors.Wrap(err)
+}
+
+// podTemplateLabels returns the labels given to the pods of a workload.
+func podTemplateLabels(w *resource.Resource) (map[string]string, error) {
+	path := filtersutil.PodSpecPath(w.GetKind())
+	template, err := w.Pipe(yaml.Lookup(path[:len(path)-1]...))
+	if err != nil || template == nil {
+		return nil, errors.Wrap(err)
+	}
+	return template.GetLabels(), nil
+}
+
+// displayName returns the kind and the (namespaced) name of r.
+func displayName(r *resource.Resource) string {
+	if ns := r.GetNamespace(); ns != "" {
+		return r.GetKind() + " " + ns + "/" + r.GetName()
+	}
+	return r.GetKind() + " " + r.GetName()
+}
+
+func labelsMatch(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func writeSelectorTestResources(th kusttest_test.Harness, otherLabel string) {
+	th.WriteK(".", `
+resources:
+- resources.yaml
+`)
+	th.WriteF("resources.yaml", `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+        track: stable
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  template:
+    metadata:
+      labels:
+        app: `+otherLabel+`
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: other
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+`)
+}
+
+func TestServiceSelectorOverlap(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeSelectorTestResources(th, "web")
+	opts := th.MakeDefaultOptions()
+
+	// The check is off by default.
+	th.Run(".", opts)
+
+	opts.FailOnServiceSelectorOverlap = true
+	err := th.RunWithErr(".", opts)
+	require.Error(t, err)
+	assert.Equal(t, `overlapping Service selectors:
+selector of Service web matches pods of Deployment web, Deployment worker`,
+		err.Error())
+}
+
+func TestServiceSelectorNoOverlap(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeSelectorTestResources(th, "worker")
+	opts := th.MakeDefaultOptions()
+	opts.FailOnServiceSelectorOverlap = true
+	th.Run(".", opts)
+}
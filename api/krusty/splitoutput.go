@@ -0,0 +1,47 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// DefaultSplitGroup is the group of RunSplit that holds the
+// resources without the Options.SplitByLabel label.
+const DefaultSplitGroup = "default"
+
+// RunSplit performs a kustomization like Run, then groups the
+// resulting resources by the value of the Options.SplitByLabel
+// label, and returns the YAML of each group, keyed by label value.
+// Resources without the label, or all of them if SplitByLabel
+// isn't set, are in the DefaultSplitGroup group. The resources of
+// a group keep their order in the output of Run.
+func (b *Kustomizer) RunSplit(
+	fSys filesys.FileSystem, path string) (map[string][]byte, error) {
+	m, err := b.Run(fSys, path)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]resmap.ResMap)
+	for _, r := range m.Resources() {
+		group, ok := r.GetLabel(b.options.SplitByLabel)
+		if b.options.SplitByLabel == "" || !ok {
+			group = DefaultSplitGroup
+		}
+		if groups[group] == nil {
+			groups[group] = resmap.New()
+		}
+		if err = groups[group].Append(r); err != nil {
+			return nil, err
+		}
+	}
+	result := make(map[string][]byte, len(groups))
+	for group, gm := range groups {
+		if result[group], err = gm.AsYaml(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
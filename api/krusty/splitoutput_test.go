@@ -0,0 +1,122 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/krusty"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestRunSplit(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resources:
+- resources.yaml
+`)
+	th.WriteF("resources.yaml", `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shared
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: alpha
+  labels:
+    tenant: alpha
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: beta
+  labels:
+    tenant: beta
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: alpha
+  labels:
+    tenant: alpha
+`)
+	opts := th.MakeDefaultOptions()
+	opts.SplitByLabel = "tenant"
+	groups, err := krusty.MakeKustomizer(&opts).RunSplit(th.GetFSys(), ".")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"alpha": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  labels:
+    tenant: alpha
+  name: config
+  namespace: alpha
+---
+apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    tenant: alpha
+  name: web
+  namespace: alpha
+`,
+		"beta": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  labels:
+    tenant: beta
+  name: config
+  namespace: beta
+`,
+		krusty.DefaultSplitGroup: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: shared
+`,
+	}, splitGroupsAsStrings(groups))
+}
+
+func TestRunSplitWithoutLabel(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	th.WriteK(".", `
+resources:
+- cm.yaml
+`)
+	th.WriteF("cm.yaml", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    tenant: alpha
+`)
+	opts := th.MakeDefaultOptions()
+	groups, err := krusty.MakeKustomizer(&opts).RunSplit(th.GetFSys(), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []string{krusty.DefaultSplitGroup}, splitGroupNames(groups))
+}
+
+func splitGroupsAsStrings(m map[string][]byte) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = string(v)
+	}
+	return result
+}
+
+func splitGroupNames(m map[string][]byte) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
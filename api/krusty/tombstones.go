@@ -0,0 +1,49 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+)
+
+// appendTombstones appends to m a tombstone for each resource of
+// the previous build output that m doesn't have. The tombstones of
+// the previous build aren't carried over.
+func (b *Kustomizer) appendTombstones(m resmap.ResMap, previous []byte) error {
+	rf := b.depProvider.GetResourceFactory()
+	prev, err := resmap.NewFactory(rf).NewResMapFromBytes(previous)
+	if err != nil {
+		return errors.WrapPrefixf(err, "unable to read previous build")
+	}
+	// The tombstones of the previous build were pruned already.
+	for _, r := range prev.Resources() {
+		if v, _ := r.GetAnnotation(konfig.PruneAnnotation); v == "true" {
+			if err = prev.Remove(r.CurId()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range resmap.AssertNoDeletions(prev, m) {
+		meta := map[string]interface{}{
+			"name": id.Name,
+			"annotations": map[string]interface{}{
+				konfig.PruneAnnotation: "true",
+			},
+		}
+		if id.Namespace != "" {
+			meta["namespace"] = id.Namespace
+		}
+		err = m.Append(rf.FromMap(map[string]interface{}{
+			"apiVersion": id.ApiVersion(),
+			"kind":       id.Kind,
+			"metadata":   meta,
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
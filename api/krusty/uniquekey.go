@@ -0,0 +1,50 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/errors"
+	kyaml_utils "sigs.k8s.io/kustomize/kyaml/utils"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// validateUniqueKey returns an error if two resources have the same
+// scalar value at the given field path. Resources without the field
+// are ignored.
+func validateUniqueKey(m resmap.ResMap, fieldPath string) error {
+	path := kyaml_utils.SmarterPathSplitter(fieldPath, ".")
+	seen := make(map[string]*resource.Resource)
+	var problems []string
+	for _, r := range m.Resources() {
+		node, err := r.Pipe(yaml.Lookup(path...))
+		if err != nil {
+			return errors.WrapPrefixf(err, "unable to look up %s", fieldPath)
+		}
+		if node.IsNilOrEmpty() {
+			continue
+		}
+		if node.YNode().Kind != yaml.ScalarNode {
+			return errors.Errorf(
+				"%s of %s is not a scalar", fieldPath, displayName(r))
+		}
+		value := node.YNode().Value
+		if other, ok := seen[value]; ok {
+			problems = append(problems, fmt.Sprintf(
+				"%s and %s have %s %q",
+				displayName(other), displayName(r), fieldPath, value))
+			continue
+		}
+		seen[value] = r
+	}
+	if len(problems) > 0 {
+		return errors.Errorf(
+			"duplicate unique key values:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
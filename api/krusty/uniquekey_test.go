@@ -0,0 +1,60 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package krusty_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func writeUniqueKeyTestResources(th kusttest_test.Harness, workerID string) {
+	th.WriteK(".", `
+resources:
+- resources.yaml
+`)
+	th.WriteF("resources.yaml", `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    example.com/id: "1001"
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  annotations:
+    example.com/id: "`+workerID+`"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`)
+}
+
+func TestUniqueKeyDuplicate(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeUniqueKeyTestResources(th, "1001")
+	opts := th.MakeDefaultOptions()
+	opts.UniqueKey = "metadata.annotations.[example.com/id]"
+	err := th.RunWithErr(".", opts)
+	require.Error(t, err)
+	assert.Equal(t, "duplicate unique key values:\n"+
+		`Deployment web and Deployment worker have `+
+		`metadata.annotations.[example.com/id] "1001"`, err.Error())
+}
+
+func TestUniqueKeyUnique(t *testing.T) {
+	th := kusttest_test.MakeHarness(t)
+	writeUniqueKeyTestResources(th, "1002")
+	opts := th.MakeDefaultOptions()
+	opts.UniqueKey = "metadata.annotations.[example.com/id]"
+	m := th.Run(".", opts)
+	assert.Equal(t, 3, m.Size())
+}
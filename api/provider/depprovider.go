@@ -22,7 +22,13 @@ type DepProvider struct {
 }
 
 func NewDepProvider() *DepProvider {
-	rf := resource.NewFactory(&hasher.Hasher{})
+	return NewDepProviderWithHasher(&hasher.Hasher{})
+}
+
+// NewDepProviderWithHasher returns a DepProvider whose resource
+// factory uses h to compute the name suffix of generated resources.
+func NewDepProviderWithHasher(h ifc.KustHasher) *DepProvider {
+	rf := resource.NewFactory(h)
 	return &DepProvider{
 		resourceFactory: rf,
 		fieldValidator:  validate.NewFieldValidator(),
@@ -0,0 +1,25 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap
+
+import (
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+// AssertNoDeletions returns the ids of the resources of prev that
+// have no counterpart with the same current id in curr, in the
+// order they appear in prev. An empty result means that curr
+// doesn't drop any resource of prev.
+//
+// Resources are matched by current id, so a generated ConfigMap
+// or Secret whose name hash changed counts as deleted.
+func AssertNoDeletions(prev, curr ResMap) []resid.ResId {
+	var deleted []resid.ResId
+	for _, id := range prev.AllIds() {
+		if len(curr.GetMatchingResourcesByCurrentId(id.Equals)) == 0 {
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted
+}
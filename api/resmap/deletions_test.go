@@ -0,0 +1,60 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+func TestAssertNoDeletions(t *testing.T) {
+	prev, err := rmF.NewResMapFromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: prod
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: prod
+`))
+	require.NoError(t, err)
+	curr, err := rmF.NewResMapFromBytes([]byte(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: prod
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: added
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]resid.ResId{resid.NewResIdWithNamespace(
+			resid.NewGvk("apps", "v1", "Deployment"), "app", "prod")},
+		AssertNoDeletions(prev, curr))
+	assert.Empty(t, AssertNoDeletions(prev, prev))
+	assert.Empty(t, AssertNoDeletions(New(), curr))
+}
@@ -4,6 +4,8 @@
 package resmap
 
 import (
+	"log"
+
 	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/internal/kusterr"
 	"sigs.k8s.io/kustomize/api/resource"
@@ -73,6 +75,35 @@ func (rmF *Factory) NewResMapFromBytes(b []byte) (ResMap, error) {
 	return newResMapFromResourceSlice(resources)
 }
 
+// NewResMapFromConfigMapManifests returns the resources in the data
+// values of a ConfigMap that holds YAML manifests, reading the values
+// in key order. Values that aren't YAML manifests are skipped with a
+// warning.
+func (rmF *Factory) NewResMapFromConfigMapManifests(
+	cm *resource.Resource) (ResMap, error) {
+	if cm.GetKind() != "ConfigMap" {
+		return nil, errors.Errorf("%s is not a ConfigMap", cm.CurId())
+	}
+	data := cm.GetDataMap()
+	m := New()
+	for _, k := range yaml.SortedMapKeys(data) {
+		resources, err := rmF.resF.SliceFromBytes([]byte(data[k]))
+		if err != nil || len(resources) == 0 {
+			log.Printf(
+				"Warning: skipping data key %q of %s; it is not a YAML manifest",
+				k, cm.CurId())
+			continue
+		}
+		for _, r := range resources {
+			if err = m.Append(r); err != nil {
+				return nil, errors.WrapPrefixf(
+					err, "data key %q of %s", k, cm.CurId())
+			}
+		}
+	}
+	return m, nil
+}
+
 // NewResMapFromConfigMapArgs returns a Resource slice given
 // a configmap metadata slice from kustomization file.
 func (rmF *Factory) NewResMapFromConfigMapArgs(
@@ -4,10 +4,14 @@
 package resmap_test
 
 import (
+	"bytes"
 	"encoding/base64"
+	"log"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/kv"
 	"sigs.k8s.io/kustomize/api/loader"
@@ -352,3 +356,59 @@ metadata:
 		})
 	}
 }
+
+func TestNewResMapFromConfigMapManifests(t *testing.T) {
+	cm, err := rmF.RF().FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: manifests
+data:
+  app.yaml: |
+    apiVersion: apps/v1
+    kind: Deployment
+    metadata:
+      name: app
+  notes.txt: just some notes
+  svc.yaml: |
+    apiVersion: v1
+    kind: Service
+    metadata:
+      name: app
+`))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	m, err := rmF.NewResMapFromConfigMapManifests(cm)
+	require.NoError(t, err)
+	expected, err := rmF.NewResMapFromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`))
+	require.NoError(t, err)
+	require.NoError(t, expected.ErrorIfNotEqualLists(m))
+	assert.Contains(t, buf.String(),
+		`Warning: skipping data key "notes.txt" of ConfigMap.v1.[noGrp]/manifests.[noNs]; it is not a YAML manifest`)
+}
+
+func TestNewResMapFromConfigMapManifestsNotAConfigMap(t *testing.T) {
+	r, err := rmF.RF().FromBytes([]byte(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: manifests
+`))
+	require.NoError(t, err)
+	_, err = rmF.NewResMapFromConfigMapManifests(r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a ConfigMap")
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap
+
+// HashManifestEntry identifies a resource and the hash of its content.
+type HashManifestEntry struct {
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string `json:"kind" yaml:"kind"`
+	Namespace  string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name       string `json:"name" yaml:"name"`
+	Hash       string `json:"hash" yaml:"hash"`
+}
+
+// MakeHashManifest returns an entry for each resource of m, in
+// order, holding the resource's Resource.ContentHash. Emitted next
+// to a build's output, it lets consumers check that the output
+// didn't change in transit.
+func MakeHashManifest(m ResMap) ([]HashManifestEntry, error) {
+	entries := make([]HashManifestEntry, 0, m.Size())
+	for _, r := range m.Resources() {
+		hash, err := r.ContentHash()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, HashManifestEntry{
+			APIVersion: r.GetApiVersion(),
+			Kind:       r.GetKind(),
+			Namespace:  r.GetNamespace(),
+			Name:       r.GetName(),
+			Hash:       hash,
+		})
+	}
+	return entries, nil
+}
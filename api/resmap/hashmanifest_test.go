@@ -0,0 +1,51 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resmap"
+)
+
+func TestMakeHashManifest(t *testing.T) {
+	m := mustResMap(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  a: b
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: prod
+spec:
+  replicas: 1
+`)
+	entries, err := MakeHashManifest(m)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, HashManifestEntry{
+		APIVersion: "v1", Kind: "ConfigMap", Name: "cm",
+		Hash: entries[0].Hash,
+	}, entries[0])
+	assert.Equal(t, HashManifestEntry{
+		APIVersion: "apps/v1", Kind: "Deployment", Namespace: "prod",
+		Name: "app", Hash: entries[1].Hash,
+	}, entries[1])
+	assert.Len(t, entries[0].Hash, 64)
+	assert.NotEqual(t, entries[0].Hash, entries[1].Hash)
+
+	// Changing one resource changes only its hash.
+	m.GetByIndex(1).SetDataMap(map[string]string{"x": "y"})
+	changed, err := MakeHashManifest(m)
+	require.NoError(t, err)
+	assert.Equal(t, entries[0].Hash, changed[0].Hash)
+	assert.NotEqual(t, entries[1].Hash, changed[1].Hash)
+}
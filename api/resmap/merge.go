@@ -0,0 +1,58 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap
+
+import (
+	"sigs.k8s.io/kustomize/kyaml/errors"
+)
+
+// ConflictPolicy decides what MergeResMaps does with two
+// resources that have the same current id.
+type ConflictPolicy int
+
+const (
+	// ConflictPolicyError fails the merge.
+	ConflictPolicyError ConflictPolicy = iota
+
+	// ConflictPolicyKeepFirst keeps the resource of the first ResMap.
+	ConflictPolicyKeepFirst
+
+	// ConflictPolicyKeepSecond keeps the resource of the second ResMap,
+	// at the position of the one it replaces.
+	ConflictPolicyKeepSecond
+)
+
+// MergeResMaps returns a new ResMap holding copies of the resources
+// of a, followed by those of b whose current id isn't already in a.
+// Resources of b whose current id is in a are handled per policy.
+//
+// Both ResMaps are expected to be fully built, so references
+// between resources are by final name and need no rewriting; a
+// resource kept from either side is still found under the same
+// name by the resources that refer to it.
+func MergeResMaps(a, b ResMap, policy ConflictPolicy) (ResMap, error) {
+	result := a.DeepCopy()
+	for _, r := range b.Resources() {
+		id := r.CurId()
+		if len(result.GetMatchingResourcesByCurrentId(id.Equals)) == 0 {
+			if err := result.Append(r.DeepCopy()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		switch policy {
+		case ConflictPolicyKeepFirst:
+		case ConflictPolicyKeepSecond:
+			if _, err := result.Replace(r.DeepCopy()); err != nil {
+				return nil, err
+			}
+		case ConflictPolicyError:
+			return nil, errors.Errorf(
+				"cannot merge resource maps: both contain %s", id)
+		default:
+			return nil, errors.Errorf("unknown conflict policy %d", policy)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resmap"
+)
+
+func mustResMap(t *testing.T, s string) ResMap {
+	t.Helper()
+	m, err := rmF.NewResMapFromBytes([]byte(s))
+	require.NoError(t, err)
+	return m
+}
+
+func TestMergeResMapsDisjoint(t *testing.T) {
+	a := mustResMap(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+`)
+	b := mustResMap(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`)
+	m, err := MergeResMaps(a, b, ConflictPolicyError)
+	require.NoError(t, err)
+	yml, err := m.AsYaml()
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`, string(yml))
+	// The inputs are left alone.
+	assert.Equal(t, 1, a.Size())
+	assert.Equal(t, 1, b.Size())
+}
+
+func TestMergeResMapsConflict(t *testing.T) {
+	a := mustResMap(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared
+data:
+  from: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: onlyA
+`)
+	b := mustResMap(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: shared
+data:
+  from: b
+`)
+	_, err := MergeResMaps(a, b, ConflictPolicyError)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both contain")
+
+	m, err := MergeResMaps(a, b, ConflictPolicyKeepFirst)
+	require.NoError(t, err)
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, map[string]string{"from": "a"}, m.GetByIndex(0).GetDataMap())
+
+	m, err = MergeResMaps(a, b, ConflictPolicyKeepSecond)
+	require.NoError(t, err)
+	assert.Equal(t, 2, m.Size())
+	assert.Equal(t, map[string]string{"from": "b"}, m.GetByIndex(0).GetDataMap())
+	assert.Equal(t, "onlyA", m.GetByIndex(1).GetName())
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/fieldspec"
+	"sigs.k8s.io/kustomize/api/internal/plugins/builtinconfig"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// wellKnownExternalReferences are objects that clusters provide,
+// so references to them needn't resolve in the ResMap. An empty
+// name allows every object of the kind.
+func wellKnownExternalReferences() []resid.ResId {
+	return []resid.ResId{
+		{Gvk: resid.Gvk{Kind: "ServiceAccount"}, Name: "default"},
+		{Gvk: resid.Gvk{Kind: "ClusterRole"}, Name: "cluster-admin"},
+		{Gvk: resid.Gvk{Kind: "ClusterRole"}, Name: "admin"},
+		{Gvk: resid.Gvk{Kind: "ClusterRole"}, Name: "edit"},
+		{Gvk: resid.Gvk{Kind: "ClusterRole"}, Name: "view"},
+		{Gvk: resid.Gvk{Kind: "PriorityClass"}, Name: "system-cluster-critical"},
+		{Gvk: resid.Gvk{Kind: "PriorityClass"}, Name: "system-node-critical"},
+	}
+}
+
+// reference is a name reference held by a resource.
+type reference struct {
+	path      string
+	kind      string
+	name      string
+	namespace string
+}
+
+// ValidateReferences implements ResMap.
+func (m *resWrangler) ValidateReferences(external ...resid.ResId) []error {
+	external = append(external, wellKnownExternalReferences()...)
+	var errs []error
+	for _, backRef := range builtinconfig.MakeDefaultConfig().NameReference {
+		target := backRef.Gvk
+		for _, referrer := range m.rList {
+			refs, err := findReferences(referrer, backRef)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, ref := range refs {
+				if isExternalReference(ref, external) || m.resolves(target, ref) {
+					continue
+				}
+				errs = append(errs, fmt.Errorf(
+					"%s refers to %s %q in %s, which does not exist",
+					referrer.CurId(), ref.kind, ref.name, ref.path))
+			}
+		}
+	}
+	return errs
+}
+
+// resolves returns true if a resource of the target kind has the
+// referenced name and, unless the target is cluster-scoped, namespace.
+func (m *resWrangler) resolves(target resid.Gvk, ref reference) bool {
+	for _, r := range m.rList {
+		id := r.CurId()
+		if !id.IsSelected(&target) || id.Name != ref.name {
+			continue
+		}
+		if id.IsClusterScoped() ||
+			id.EffectiveNamespace() == resid.NewResIdWithNamespace(
+				id.Gvk, id.Name, ref.namespace).EffectiveNamespace() {
+			return true
+		}
+	}
+	return false
+}
+
+func isExternalReference(ref reference, external []resid.ResId) bool {
+	if ref.kind == "ClusterRole" && strings.HasPrefix(ref.name, "system:") {
+		return true
+	}
+	for _, id := range external {
+		if id.Kind == ref.kind && (id.Name == "" || id.Name == ref.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// findReferences returns the references that the referrer holds,
+// in the fields given by the referrer specs of backRef, to objects
+// of the kind of backRef. Optional references are skipped.
+func findReferences(
+	referrer *resource.Resource,
+	backRef builtinconfig.NameBackReferences) ([]reference, error) {
+	var refs []reference
+	var add func(fsPath string, node, parent *yaml.RNode)
+	add = func(fsPath string, node, parent *yaml.RNode) {
+		switch {
+		case node == nil || yaml.IsMissingOrNull(node):
+		case node.YNode().Kind == yaml.SequenceNode:
+			for _, elem := range node.Content() {
+				add(fsPath, yaml.NewRNode(elem), parent)
+			}
+		case node.YNode().Kind == yaml.ScalarNode:
+			if parent != nil && isOptionalReference(parent, backRef.Kind) {
+				return
+			}
+			if name := node.YNode().Value; name != "" {
+				refs = append(refs, reference{
+					path: fsPath, kind: backRef.Kind, name: name,
+					namespace: referrer.GetNamespace(),
+				})
+			}
+		case node.YNode().Kind == yaml.MappingNode:
+			if isOptionalReference(node, backRef.Kind) {
+				return
+			}
+			name := stringField(node, "name")
+			if name == "" {
+				return
+			}
+			namespace := stringField(node, "namespace")
+			if namespace == "" {
+				namespace = referrer.GetNamespace()
+			}
+			refs = append(refs, reference{
+				path: fsPath, kind: backRef.Kind, name: name, namespace: namespace,
+			})
+		}
+	}
+	for _, fs := range backRef.Referrers {
+		// The resourceNames of RBAC rules don't say which kind of
+		// object they name.
+		if fs.Path == "rules/resourceNames" ||
+			!referrer.CurId().IsSelected(&fs.Gvk) {
+			continue
+		}
+		fsPath := fs.Path
+		i := strings.LastIndex(fsPath, "/")
+		if i < 0 {
+			// The field is at the top level, e.g. subjects.
+			add(fsPath, fieldValue(&referrer.RNode, fsPath), nil)
+			continue
+		}
+		leaf := fsPath[i+1:]
+		fs.Path = fsPath[:i]
+		fs.Gvk = referrer.GetGvk()
+		err := referrer.PipeE(fieldspec.Filter{
+			FieldSpec: fs,
+			SetValue: func(parent *yaml.RNode) error {
+				if parent.YNode().Kind != yaml.SequenceNode {
+					add(fsPath, fieldValue(parent, leaf), parent)
+					return nil
+				}
+				return parent.VisitElements(func(elem *yaml.RNode) error {
+					add(fsPath, fieldValue(elem, leaf), elem)
+					return nil
+				})
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot find references in %s of %s: %w", fsPath, referrer.CurId(), err)
+		}
+	}
+	return refs, nil
+}
+
+// isOptionalReference returns true if the map holding a reference
+// marks it optional, or says it refers to an object of another kind,
+// like the roleRef of a RoleBinding and the subjects of a binding.
+func isOptionalReference(node *yaml.RNode, kind string) bool {
+	if node.YNode().Kind != yaml.MappingNode {
+		return false
+	}
+	if stringField(node, "optional") == "true" {
+		return true
+	}
+	k := stringField(node, "kind")
+	return k != "" && k != kind
+}
+
+func fieldValue(node *yaml.RNode, name string) *yaml.RNode {
+	field := node.Field(name)
+	if field.IsNilOrEmpty() {
+		return nil
+	}
+	return field.Value
+}
+
+func stringField(node *yaml.RNode, name string) string {
+	if value := fieldValue(node, name); value != nil {
+		return yaml.GetValue(value)
+	}
+	return ""
+}
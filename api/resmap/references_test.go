@@ -0,0 +1,111 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resmap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+const referencesDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: prod
+spec:
+  template:
+    spec:
+      serviceAccountName: default
+      containers:
+      - name: app
+        env:
+        - name: TOKEN
+          valueFrom:
+            secretKeyRef:
+              name: token
+              key: token
+              optional: true
+      volumes:
+      - name: config
+        configMap:
+          name: app-config
+`
+
+func TestValidateReferencesResolved(t *testing.T) {
+	m, err := rmF.NewResMapFromBytes([]byte(referencesDeployment + `
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: prod
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: viewers
+  namespace: prod
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: view
+subjects:
+- kind: ServiceAccount
+  name: default
+  namespace: prod
+- kind: User
+  name: alice
+`))
+	require.NoError(t, err)
+	assert.Empty(t, m.ValidateReferences())
+}
+
+func TestValidateReferencesDangling(t *testing.T) {
+	m, err := rmF.NewResMapFromBytes([]byte(referencesDeployment + `
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: staging
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: deployers
+  namespace: prod
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: deployer
+subjects:
+- kind: ServiceAccount
+  name: ci
+`))
+	require.NoError(t, err)
+	errs := m.ValidateReferences()
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.ElementsMatch(t, []string{
+		`Deployment.v1.apps/app.prod refers to ConfigMap "app-config" in ` +
+			`spec/template/spec/volumes/configMap/name, which does not exist`,
+		`RoleBinding.v1.rbac.authorization.k8s.io/deployers.prod refers to ` +
+			`ServiceAccount "ci" in subjects, which does not exist`,
+		`RoleBinding.v1.rbac.authorization.k8s.io/deployers.prod refers to ` +
+			`Role "deployer" in roleRef/name, which does not exist`,
+	}, messages)
+
+	// References to external objects can be allowed by kind or name.
+	errs = m.ValidateReferences(
+		resid.ResId{Gvk: resid.Gvk{Kind: "ConfigMap"}, Name: "app-config"},
+		resid.ResId{Gvk: resid.Gvk{Kind: "Role"}},
+		resid.ResId{Gvk: resid.Gvk{Kind: "ServiceAccount"}, Name: "ci"})
+	assert.Empty(t, errs)
+}
@@ -114,6 +114,21 @@ type ResMap interface {
 	// as appended.
 	Resources() []*resource.Resource
 
+	// ForEach calls fn on each resource, in order, stopping at
+	// the first error, which it returns wrapped with the CurId
+	// of the resource.
+	ForEach(fn func(*resource.Resource) error) error
+
+	// ValidateReferences returns an error for each reference,
+	// in the fields known to the name reference transformer, to
+	// an object that isn't in the ResMap, e.g. a Deployment
+	// mounting a missing ConfigMap. Optional references,
+	// references to well-known objects that clusters provide,
+	// like the default ServiceAccount, and references to the
+	// given external objects are skipped. An external id with
+	// an empty name matches every object of its kind.
+	ValidateReferences(external ...resid.ResId) []error
+
 	// Append adds a Resource. Error on CurId collision.
 	//
 	// A class invariant of ResMap is that all of its
@@ -176,6 +191,13 @@ type ResMap interface {
 	// AsYaml returns the yaml form of resources.
 	AsYaml() ([]byte, error)
 
+	// AsYamlPreservingComments returns the yaml form of
+	// resources, written directly from their nodes rather
+	// than via json, so that comments from the input files
+	// survive on fields that no transformer rewrote.
+	// Unlike AsYaml, fields keep the order they were read in.
+	AsYamlPreservingComments() ([]byte, error)
+
 	// GetByIndex returns a resource at the given index,
 	// nil if out of range.
 	GetByIndex(int) *resource.Resource
@@ -72,6 +72,16 @@ func (m *resWrangler) Resources() []*resource.Resource {
 	return tmp
 }
 
+// ForEach implements ResMap.
+func (m *resWrangler) ForEach(fn func(*resource.Resource) error) error {
+	for _, r := range m.Resources() {
+		if err := fn(r); err != nil {
+			return errors.WrapPrefixf(err, "%s", r.CurId())
+		}
+	}
+	return nil
+}
+
 // Append implements ResMap.
 func (m *resWrangler) Append(res *resource.Resource) error {
 	id := res.CurId()
@@ -302,6 +312,15 @@ func (m *resWrangler) AsYaml() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// AsYamlPreservingComments implements ResMap.
+func (m *resWrangler) AsYamlPreservingComments() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := (&kio.ByteWriter{Writer: &buf}).Write(m.ToRNodeSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // ErrorIfNotEqualSets implements ResMap.
 func (m *resWrangler) ErrorIfNotEqualSets(other ResMap) error {
 	m2, ok := other.(*resWrangler)
@@ -587,6 +606,9 @@ func (m *resWrangler) appendReplaceOrMerge(res *resource.Resource) error {
 			res.CopyMergeMetaDataFieldsFrom(old)
 			res.MergeDataMapFrom(old)
 			res.MergeBinaryDataMapFrom(old)
+			if res.GetKind() == "Secret" {
+				res.MergeStringDataMapFrom(old)
+			}
 			if orig != nil {
 				res.SetOrigin(orig)
 			}
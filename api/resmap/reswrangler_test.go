@@ -130,6 +130,32 @@ func TestAppendRemove(t *testing.T) {
 	}
 }
 
+func TestForEach(t *testing.T) {
+	w := New()
+	doAppend(t, w, makeCm(1))
+	doAppend(t, w, makeCm(2))
+	doAppend(t, w, makeCm(3))
+
+	var names []string
+	err := w.ForEach(func(r *resource.Resource) error {
+		names = append(names, r.GetName())
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cm001", "cm002", "cm003"}, names)
+
+	names = nil
+	err = w.ForEach(func(r *resource.Resource) error {
+		names = append(names, r.GetName())
+		if r.GetName() == "cm002" {
+			return fmt.Errorf("boom")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "ConfigMap.v1.[noGrp]/cm002.[noNs]: boom")
+	assert.Equal(t, []string{"cm001", "cm002"}, names)
+}
+
 func TestRemove(t *testing.T) {
 	w := New()
 	r := makeCm(1)
@@ -637,6 +663,38 @@ func TestDeepCopy(t *testing.T) {
 	}
 }
 
+func BenchmarkDeepCopy(b *testing.B) {
+	for _, count := range []int{10, 100, 1000} {
+		m := New()
+		for i := 0; i < count; i++ {
+			assert.NoError(b, m.Append(rf.FromMap(map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":   fmt.Sprintf("app-%d", i),
+					"labels": map[string]interface{}{"app": "web"},
+				},
+				"spec": map[string]interface{}{
+					"replicas": 2,
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "web", "image": "web:v1"},
+							},
+						},
+					},
+				},
+			})))
+		}
+		b.Run(fmt.Sprintf("%04d", count), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = m.DeepCopy()
+			}
+		})
+	}
+}
+
 func TestErrorIfNotEqualSets(t *testing.T) {
 	r1 := rf.FromMap(
 		map[string]interface{}{
@@ -0,0 +1,113 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldChange is a difference between two resources at one field.
+type FieldChange struct {
+	// Path is the dot-separated path of the field, e.g.
+	// spec.template.spec.containers[0].image. Keys that contain
+	// dots are put in brackets, e.g. metadata.labels.[example.com/app].
+	Path string
+	// Old is the value in the original resource, or nil if the
+	// field was added.
+	Old interface{}
+	// New is the value in the other resource, or nil if the
+	// field was removed.
+	New interface{}
+}
+
+// Diff returns the fields that differ between r and other, which
+// must have the same current id. Only leaf fields are reported,
+// unless a field changed type, e.g. from a map to a string, or was
+// added or removed, in which case the whole value is reported.
+// Changes are ordered by path, with map keys in sorted order and
+// list elements in list order.
+func (r *Resource) Diff(other *Resource) ([]FieldChange, error) {
+	if !r.CurId().Equals(other.CurId()) {
+		return nil, fmt.Errorf(
+			"cannot diff %s with %s; ids differ", r.CurId(), other.CurId())
+	}
+	oldMap, err := r.Map()
+	if err != nil {
+		return nil, err
+	}
+	newMap, err := other.Map()
+	if err != nil {
+		return nil, err
+	}
+	var changes []FieldChange
+	diffValues("", oldMap, newMap, &changes)
+	return changes, nil
+}
+
+func diffValues(path string, o, n interface{}, changes *[]FieldChange) {
+	switch ov := o.(type) {
+	case map[string]interface{}:
+		if nv, ok := n.(map[string]interface{}); ok {
+			diffMaps(path, ov, nv, changes)
+			return
+		}
+	case []interface{}:
+		if nv, ok := n.([]interface{}); ok {
+			diffLists(path, ov, nv, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(o, n) {
+		*changes = append(*changes, FieldChange{Path: path, Old: o, New: n})
+	}
+}
+
+func diffMaps(path string, o, n map[string]interface{}, changes *[]FieldChange) {
+	keys := make([]string, 0, len(o)+len(n))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	for k := range n {
+		if _, ok := o[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		field := k
+		if strings.Contains(k, ".") {
+			field = "[" + k + "]"
+		}
+		if path != "" {
+			field = path + "." + field
+		}
+		ov, inOld := o[k]
+		nv, inNew := n[k]
+		switch {
+		case !inOld:
+			*changes = append(*changes, FieldChange{Path: field, New: nv})
+		case !inNew:
+			*changes = append(*changes, FieldChange{Path: field, Old: ov})
+		default:
+			diffValues(field, ov, nv, changes)
+		}
+	}
+}
+
+func diffLists(path string, o, n []interface{}, changes *[]FieldChange) {
+	for i := 0; i < len(o) || i < len(n); i++ {
+		elem := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(o):
+			*changes = append(*changes, FieldChange{Path: elem, New: n[i]})
+		case i >= len(n):
+			*changes = append(*changes, FieldChange{Path: elem, Old: o[i]})
+		default:
+			diffValues(elem, o[i], n[i], changes)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resource"
+)
+
+func TestDiff(t *testing.T) {
+	r1, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: web
+    example.com/tier: frontend
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+        args: [--verbose]
+      - name: proxy
+        image: proxy:v1
+`))
+	require.NoError(t, err)
+	r2, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: web
+    example.com/tier: backend
+spec:
+  paused: true
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v2
+        args: --quiet
+`))
+	require.NoError(t, err)
+
+	changes, err := r1.Diff(r2)
+	require.NoError(t, err)
+	assert.Equal(t, []FieldChange{
+		{Path: "metadata.labels.[example.com/tier]", Old: "frontend", New: "backend"},
+		{Path: "spec.paused", New: true},
+		{Path: "spec.replicas", Old: 2},
+		{Path: "spec.template.spec.containers[0].args",
+			Old: []interface{}{"--verbose"}, New: "--quiet"},
+		{Path: "spec.template.spec.containers[0].image", Old: "app:v1", New: "app:v2"},
+		{Path: "spec.template.spec.containers[1]",
+			Old: map[string]interface{}{"name": "proxy", "image": "proxy:v1"}},
+	}, changes)
+
+	changes, err = r1.Diff(r1.DeepCopy())
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDiffDifferentIds(t *testing.T) {
+	r1, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+`))
+	require.NoError(t, err)
+	r2, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`))
+	require.NoError(t, err)
+	_, err = r1.Diff(r2)
+	require.Error(t, err)
+	assert.Equal(t,
+		"cannot diff ConfigMap.v1.[noGrp]/a.[noNs] with ConfigMap.v1.[noGrp]/b.[noNs]; ids differ",
+		err.Error())
+}
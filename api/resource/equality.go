@@ -0,0 +1,206 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	kyaml_utils "sigs.k8s.io/kustomize/kyaml/utils"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// listMergeKeys are the fields, in order of preference, that
+// identify the elements of a list of maps, e.g. the name of a
+// container or the mountPath of a volume mount.
+var listMergeKeys = []string{"name", "mountPath", "containerPort", "devicePath"}
+
+// EqualsSemantic returns true if r and o hold the same object,
+// ignoring the order of map keys and of list elements where order
+// doesn't matter: lists of scalars are compared as sorted lists, and
+// lists of maps that all have a merge key, e.g. the containers of a
+// pod spec keyed by name, are matched by that key. Other lists must
+// have the same order.
+func (r *Resource) EqualsSemantic(o *Resource) bool {
+	rMap, err := r.Map()
+	if err != nil {
+		panic(err)
+	}
+	oMap, err := o.Map()
+	if err != nil {
+		panic(err)
+	}
+	return reflect.DeepEqual(normalize(rMap), normalize(oMap))
+}
+
+// EqualsIgnoring returns true if r and o hold the same object once
+// the fields at the given dot-separated paths, e.g. "status" or
+// "metadata.annotations.[example.com/revision]", are removed from
+// both. Neither resource is changed.
+func (r *Resource) EqualsIgnoring(o *Resource, paths []string) bool {
+	rMap, err := withoutFields(r, paths).Map()
+	if err != nil {
+		panic(err)
+	}
+	oMap, err := withoutFields(o, paths).Map()
+	if err != nil {
+		panic(err)
+	}
+	return reflect.DeepEqual(rMap, oMap)
+}
+
+// EqualsIgnoringNamespace returns true if r and o hold the same
+// object apart from their namespaces, e.g. the same resource built
+// for two environments.
+func (r *Resource) EqualsIgnoringNamespace(o *Resource) bool {
+	return r.EqualsIgnoring(o, []string{"metadata.namespace"})
+}
+
+// SameIdIgnoringNamespace returns true if r and o have the same
+// current group, version, kind and name, whatever their namespaces.
+func (r *Resource) SameIdIgnoringNamespace(o *Resource) bool {
+	return r.CurId().GvknEquals(o.CurId())
+}
+
+// withoutFields returns a copy of r with the fields at the given
+// paths removed.
+func withoutFields(r *Resource, paths []string) *Resource {
+	rc := r.DeepCopy()
+	for _, path := range paths {
+		fields := kyaml_utils.SmarterPathSplitter(path, ".")
+		parent, err := rc.Pipe(kyaml.Lookup(fields[:len(fields)-1]...))
+		if err != nil {
+			panic(err)
+		}
+		if parent == nil {
+			continue
+		}
+		if err = parent.PipeE(kyaml.Clear(fields[len(fields)-1])); err != nil {
+			panic(err)
+		}
+	}
+	return rc
+}
+
+// normalize returns a copy of the value with its lists sorted as
+// described in EqualsSemantic.
+func normalize(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, elem := range value {
+			result[k] = normalize(elem)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, elem := range value {
+			result[i] = normalize(elem)
+		}
+		if sortKey := listSortKey(result); sortKey != nil {
+			sort.SliceStable(result, func(i, j int) bool {
+				return sortKey(result[i]) < sortKey(result[j])
+			})
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// listSortKey returns a function giving the sort key of the elements
+// of the list, or nil if the order of the list matters.
+func listSortKey(list []interface{}) func(interface{}) string {
+	if len(list) < 2 {
+		return nil
+	}
+	if allScalars(list) {
+		return func(v interface{}) string {
+			return fmt.Sprintf("%T:%v", v, v)
+		}
+	}
+	for _, key := range listMergeKeys {
+		if isMergeKey(list, key) {
+			return func(v interface{}) string {
+				return fmt.Sprint(v.(map[string]interface{})[key])
+			}
+		}
+	}
+	return nil
+}
+
+func allScalars(list []interface{}) bool {
+	for _, elem := range list {
+		switch elem.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+// isMergeKey returns true if every element of the list is a map
+// with a distinct scalar value for the key.
+func isMergeKey(list []interface{}, key string) bool {
+	seen := make(map[string]bool, len(list))
+	for _, elem := range list {
+		m, ok := elem.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		v, ok := m[key]
+		if !ok || !allScalars([]interface{}{v}) {
+			return false
+		}
+		s := fmt.Sprint(v)
+		if seen[s] {
+			return false
+		}
+		seen[s] = true
+	}
+	return true
+}
+
+// IdComparison is the result of comparing two resources with
+// CompareId.
+type IdComparison int
+
+const (
+	// Distinct resources have different ids.
+	Distinct IdComparison = iota
+	// Same resources have the same id and the same content,
+	// i.e. one is a duplicate of the other.
+	Same
+	// Conflict resources have the same id but different content.
+	Conflict
+)
+
+// CompareId tells whether r and o have the same current id and,
+// if so, whether their content, other than kustomize's internal
+// annotations, is the same.
+func (r *Resource) CompareId(o *Resource) IdComparison {
+	if !r.CurId().Equals(o.CurId()) {
+		return Distinct
+	}
+	rBytes, err := r.ToUnstructuredBytes()
+	if err != nil {
+		panic(err)
+	}
+	oBytes, err := o.ToUnstructuredBytes()
+	if err != nil {
+		panic(err)
+	}
+	if !bytes.Equal(rBytes, oBytes) {
+		return Conflict
+	}
+	return Same
+}
+
+// ConflictsWith returns true if r and o have the same current id
+// but different content.
+func (r *Resource) ConflictsWith(o *Resource) bool {
+	return r.CompareId(o) == Conflict
+}
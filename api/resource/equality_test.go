@@ -0,0 +1,262 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resource"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+func TestEqualsSemantic(t *testing.T) {
+	base := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+        args: [--verbose, --port=80]
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "2"
+      - name: sidecar
+        image: proxy:v1
+      tolerations:
+      - key: a
+      - key: b
+`
+	tests := map[string]struct {
+		other string
+		equal bool
+	}{
+		"reordered keys, containers, env and args": {
+			other: `
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      tolerations:
+      - key: a
+      - key: b
+      containers:
+      - image: proxy:v1
+        name: sidecar
+      - name: app
+        image: app:v1
+        args: [--port=80, --verbose]
+        env:
+        - name: B
+          value: "2"
+        - name: A
+          value: "1"
+`,
+			equal: true,
+		},
+		"reordered list without a merge key": {
+			other: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+        args: [--verbose, --port=80]
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "2"
+      - name: sidecar
+        image: proxy:v1
+      tolerations:
+      - key: b
+      - key: a
+`,
+			equal: false,
+		},
+		"different value": {
+			other: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: sidecar
+        image: proxy:v1
+      - name: app
+        image: app:v2
+        args: [--verbose, --port=80]
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "2"
+      tolerations:
+      - key: a
+      - key: b
+`,
+			equal: false,
+		},
+	}
+	r, err := factory.FromBytes([]byte(base))
+	require.NoError(t, err)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o, err := factory.FromBytes([]byte(tc.other))
+			require.NoError(t, err)
+			assert.Equal(t, tc.equal, r.EqualsSemantic(o))
+			assert.Equal(t, tc.equal, o.EqualsSemantic(r))
+		})
+	}
+}
+
+func TestCompareId(t *testing.T) {
+	cm := func(ns, value string, annotations map[string]string) *Resource {
+		r := factory.FromMap(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "cm",
+				"namespace": ns,
+			},
+			"data": map[string]interface{}{"mode": value},
+		})
+		if annotations != nil {
+			require.NoError(t, r.SetAnnotations(annotations))
+		}
+		return r
+	}
+	base := cm("default", "prod", nil)
+	tests := map[string]struct {
+		other    *Resource
+		expected IdComparison
+	}{
+		"duplicate": {
+			other:    cm("default", "prod", nil),
+			expected: Same,
+		},
+		"duplicate apart from internal annotations": {
+			other: cm("default", "prod", map[string]string{
+				"internal.config.kubernetes.io/refBy": "Deployment.v1.apps/app.[noNs]",
+			}),
+			expected: Same,
+		},
+		"conflict": {
+			other:    cm("default", "dev", nil),
+			expected: Conflict,
+		},
+		"distinct": {
+			other:    cm("other", "dev", nil),
+			expected: Distinct,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, base.CompareId(tc.other))
+			assert.Equal(t, tc.expected, tc.other.CompareId(base))
+			assert.Equal(t, tc.expected == Conflict, base.ConflictsWith(tc.other))
+		})
+	}
+}
+
+func TestEqualsIgnoring(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  resourceVersion: "100"
+  annotations:
+    example.com/revision: "3"
+    owner: team-a
+spec:
+  replicas: 2
+status:
+  replicas: 2
+`))
+	require.NoError(t, err)
+	o, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  resourceVersion: "200"
+  annotations:
+    example.com/revision: "4"
+    owner: team-a
+spec:
+  replicas: 2
+status:
+  replicas: 1
+`))
+	require.NoError(t, err)
+	rBefore, oBefore := r.MustString(), o.MustString()
+
+	ignored := []string{
+		"metadata.resourceVersion",
+		"metadata.annotations.[example.com/revision]",
+		"status",
+		"spec.missing.field",
+	}
+	assert.True(t, r.EqualsIgnoring(o, ignored))
+	assert.False(t, r.EqualsIgnoring(o, ignored[:2]))
+	assert.False(t, r.EqualsIgnoring(o, nil))
+	assert.Equal(t, rBefore, r.MustString())
+	assert.Equal(t, oBefore, o.MustString())
+
+	require.NoError(t, o.PipeE(kyaml.SetField("spec", kyaml.MustParse("replicas: 3"))))
+	assert.False(t, r.EqualsIgnoring(o, ignored))
+}
+
+func TestEqualsIgnoringNamespace(t *testing.T) {
+	makeResource := func(namespace, tier string) *Resource {
+		r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: ` + namespace + `
+  labels:
+    tier: ` + tier + `
+spec:
+  ports:
+  - port: 80
+`))
+		require.NoError(t, err)
+		return r
+	}
+	staging := makeResource("staging", "frontend")
+	prod := makeResource("prod", "frontend")
+	assert.True(t, staging.EqualsIgnoringNamespace(prod))
+	assert.True(t, staging.SameIdIgnoringNamespace(prod))
+	assert.False(t, staging.CurId().Equals(prod.CurId()))
+	assert.Equal(t, "staging", staging.GetNamespace())
+
+	other := makeResource("prod", "backend")
+	assert.False(t, staging.EqualsIgnoringNamespace(other))
+	assert.True(t, staging.SameIdIgnoringNamespace(other))
+
+	require.NoError(t, other.SetName("api"))
+	assert.False(t, staging.SameIdIgnoringNamespace(other))
+}
@@ -4,6 +4,7 @@
 package resource
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -14,6 +15,7 @@ import (
 	"sigs.k8s.io/kustomize/api/internal/kusterr"
 	"sigs.k8s.io/kustomize/api/konfig"
 	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/errors"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/resid"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -115,15 +117,122 @@ func (rf *Factory) FromBytes(in []byte) (*Resource, error) {
 	return result[0], nil
 }
 
+// FromBytesWithOriginalId is like FromBytes, but records origName
+// and origNs as the name and namespace the resource had before a
+// build changed them, so that OrgId returns them. It is meant for
+// deserializing resources saved in the middle of a build, e.g. in a
+// build cache; inputs to a build should be made with FromBytes.
+func (rf *Factory) FromBytesWithOriginalId(
+	in []byte, origName, origNs string) (*Resource, error) {
+	r, err := rf.FromBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.PrevIds()) > 0 {
+		return nil, fmt.Errorf(
+			"cannot set the original id of %s; it already has one", r.CurId())
+	}
+	orgId := resid.NewResIdWithNamespace(r.GetGvk(), origName, origNs)
+	if !orgId.Equals(r.CurId()) {
+		r.setPreviousId(orgId.EffectiveNamespace(), orgId.Name, orgId.Kind)
+	}
+	return r, nil
+}
+
+// FromUnstructuredBytes makes a Resource from the JSON form of a
+// single object, as produced by the MarshalJSON method of
+// apimachinery's Unstructured type or by Resource.ToUnstructuredBytes.
+func (rf *Factory) FromUnstructuredBytes(in []byte) (*Resource, error) {
+	var m map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(in))
+	// Keep integers exact; float64 can't hold every int64.
+	d.UseNumber()
+	if err := d.Decode(&m); err != nil {
+		return nil, errors.WrapPrefixf(err, "unable to decode object")
+	}
+	if m == nil {
+		return nil, errors.Errorf("expected a JSON object, got %q", in)
+	}
+	n, err := yaml.FromMap(convertJSONNumbers(m).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return rf.makeOne(n, nil), nil
+}
+
+// convertJSONNumbers replaces the json.Number values in v with
+// an int64 or, if the number isn't an integer, a float64.
+func convertJSONNumbers(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = convertJSONNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = convertJSONNumbers(e)
+		}
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i
+		}
+		if f, err := x.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
 // SliceFromBytes unmarshals bytes into a Resource slice.
+// Empty and comment-only documents are skipped. If a document
+// is invalid, the error identifies it by its index in the input,
+// counting from 0; empty documents count too.
 func (rf *Factory) SliceFromBytes(in []byte) ([]*Resource, error) {
 	nodes, err := rf.RNodesFromBytes(in)
 	if err != nil {
-		return nil, err
+		return nil, rf.locateError(in, err)
 	}
 	return rf.resourcesFromRNodes(nodes), nil
 }
 
+// locateError parses the documents of the input one at a time to
+// find the one that gave the error, and prefixes the error with its
+// index. The error is returned unchanged if no document gives an
+// error on its own, e.g. if a separator is invalid.
+func (rf *Factory) locateError(in []byte, err error) error {
+	docs := splitDocuments(string(in))
+	if len(docs) < 2 {
+		return err
+	}
+	for i, doc := range docs {
+		if _, docErr := rf.RNodesFromBytes([]byte(doc)); docErr != nil {
+			return fmt.Errorf("document %d: %w", i, docErr)
+		}
+	}
+	return err
+}
+
+// splitDocuments splits a multi-document YAML stream at the lines
+// starting with "---", the separators kio.ByteReader recognizes.
+// A separator on the first line starts the first document instead
+// of ending an empty one.
+func splitDocuments(in string) []string {
+	var docs []string
+	var doc strings.Builder
+	lines := strings.SplitAfter(strings.ReplaceAll(in, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "---") {
+			doc.WriteString(line)
+			continue
+		}
+		if i > 0 {
+			docs = append(docs, doc.String())
+			doc.Reset()
+		}
+	}
+	return append(docs, doc.String())
+}
+
 // DropLocalNodes removes the local nodes by default. Local nodes are detected via the annotation `config.kubernetes.io/local-config: "true"`
 func (rf *Factory) DropLocalNodes(nodes []*yaml.RNode) ([]*Resource, error) {
 	var result []*yaml.RNode
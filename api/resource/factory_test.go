@@ -9,11 +9,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/kustomize/api/loader"
 	. "sigs.k8s.io/kustomize/api/resource"
 	"sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/resid"
 )
 
 func TestRNodesFromBytes(t *testing.T) {
@@ -731,3 +733,189 @@ metadata:
 		})
 	}
 }
+
+func TestSliceFromBytes(t *testing.T) {
+	resources, err := factory.SliceFromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: ns
+---
+# only a comment
+---
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+`))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.Len(t, resources, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, "ConfigMap.v1.[noGrp]/cm.ns", resources[0].OrgId().String())
+	assert.Equal(t, "Deployment.v1.apps/app.[noNs]", resources[1].OrgId().String())
+}
+
+func TestSliceFromBytesErrorIdentifiesDocument(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"missing name": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+# only a comment
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {}
+`,
+			expected: "document 2: missing metadata.name",
+		},
+		"malformed": {
+			input: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+kind: [
+`,
+			expected: "document 1: MalformedYAMLError",
+		},
+		"leading separator": {
+			input: `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {}
+`,
+			expected: "document 1: missing metadata.name",
+		},
+		"adjacent separators": {
+			input: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+---
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {}
+`,
+			expected: "document 2: missing metadata.name",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := factory.SliceFromBytes([]byte(tc.input))
+			if !assert.Error(t, err) {
+				t.FailNow()
+			}
+			assert.True(t, strings.HasPrefix(err.Error(), tc.expected), err.Error())
+		})
+	}
+}
+
+func TestFromBytesWithOriginalId(t *testing.T) {
+	in := []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: prod-config
+  namespace: prod
+`)
+	r, err := factory.FromBytesWithOriginalId(in, "config", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod-config", r.GetName())
+	assert.Equal(t,
+		resid.NewResIdWithNamespace(resid.NewGvk("", "v1", "ConfigMap"), "config", "default"),
+		r.OrgId())
+	assert.Equal(t, "ConfigMap.v1.[noGrp]/prod-config.prod", r.CurId().String())
+
+	// Restoring the current id records nothing.
+	r, err = factory.FromBytesWithOriginalId(in, "prod-config", "prod")
+	assert.NoError(t, err)
+	assert.Empty(t, r.PrevIds())
+	assert.Equal(t, r.CurId(), r.OrgId())
+
+	r.StorePreviousId()
+	y, err := r.AsYAML()
+	assert.NoError(t, err)
+	_, err = factory.FromBytesWithOriginalId(y, "config", "")
+	assert.EqualError(t, err,
+		"cannot set the original id of ConfigMap.v1.[noGrp]/prod-config.prod; it already has one")
+}
+
+func TestFromUnstructuredBytes(t *testing.T) {
+	testCases := map[string]struct {
+		// Output of unstructured.Unstructured.MarshalJSON:
+		// compact, keys sorted, ending in a newline.
+		input string
+		check func(t *testing.T, r *Resource)
+	}{
+		"deployment from a cluster": {
+			input: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{` +
+				`"annotations":{"deployment.kubernetes.io/revision":"2"},` +
+				`"creationTimestamp":"2023-01-12T09:41:07Z","generation":2,` +
+				`"labels":{"app":"web"},"name":"web","namespace":"shop",` +
+				`"resourceVersion":"48213","uid":"1f0c6d6e-9f0e-4b43-a2d3-6a8e3f1f4c52"},` +
+				`"spec":{"progressDeadlineSeconds":600,"replicas":3,"revisionHistoryLimit":10,` +
+				`"selector":{"matchLabels":{"app":"web"}},` +
+				`"strategy":{"rollingUpdate":{"maxSurge":"25%","maxUnavailable":"25%"},"type":"RollingUpdate"},` +
+				`"template":{"metadata":{"creationTimestamp":null,"labels":{"app":"web"}},` +
+				`"spec":{"containers":[{"image":"nginx:1.23","name":"nginx",` +
+				`"ports":[{"containerPort":80,"protocol":"TCP"}],"resources":{}}],` +
+				`"securityContext":{"fsGroup":2000,"runAsUser":1000},` +
+				`"terminationGracePeriodSeconds":30}}},` +
+				`"status":{"observedGeneration":2,"readyReplicas":3,"replicas":3}}` + "\n",
+			check: func(t *testing.T, r *Resource) {
+				t.Helper()
+				assert.Equal(t, "Deployment.v1.apps/web.shop", r.CurId().String())
+				assert.Equal(t, map[string]string{"app": "web"}, r.GetLabels())
+				replicas, err := r.GetFieldValue("spec.replicas")
+				require.NoError(t, err)
+				assert.Equal(t, 3, replicas)
+				user, err := r.GetFieldValue("spec.template.spec.securityContext.runAsUser")
+				require.NoError(t, err)
+				assert.Equal(t, 1000, user)
+				containers, err := r.GetSlice("spec.template.spec.containers")
+				require.NoError(t, err)
+				assert.Len(t, containers, 1)
+			},
+		},
+		"custom resource with int64 beyond float64 precision": {
+			input: `{"apiVersion":"example.com/v1","kind":"Quota","metadata":{"name":"q"},` +
+				`"spec":{"limits":{"bytes":9223372036854775807,"ratio":0.75}}}` + "\n",
+			check: func(t *testing.T, r *Resource) {
+				t.Helper()
+				y, err := r.AsYAML()
+				require.NoError(t, err)
+				assert.Contains(t, string(y), "bytes: 9223372036854775807\n")
+				assert.Contains(t, string(y), "ratio: 0.75\n")
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, err := factory.FromUnstructuredBytes([]byte(tc.input))
+			require.NoError(t, err)
+			tc.check(t, r)
+			bs, err := r.ToUnstructuredBytes()
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSuffix(tc.input, "\n"), string(bs))
+		})
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	kyaml_utils "sigs.k8s.io/kustomize/kyaml/utils"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// pathSegment is a step of a field path: a map key, or an index
+// into a list.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+	// path and parentPath are the paths of the field and of the
+	// map or list holding it, for error messages.
+	path       string
+	parentPath string
+}
+
+var indexedSegment = regexp.MustCompile(`^(.*)\[(\d+)\]$`)
+
+// parseFieldPath splits a dot-separated field path, like the ones
+// GetFieldValue takes, into segments. A segment like containers[0]
+// is a map key followed by a list index, and a bracketed segment
+// like [example.com/app] is a map key that may contain dots.
+func parseFieldPath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	parentPath := ""
+	for _, s := range kyaml_utils.SmarterPathSplitter(path, ".") {
+		key, index := s, ""
+		if groups := indexedSegment.FindStringSubmatch(s); groups != nil {
+			key, index = groups[1], groups[2]
+		} else if strings.HasPrefix(s, "[") && strings.Contains(s, "=") {
+			return nil, fmt.Errorf(
+				"invalid field path %s: list entry selectors like %s aren't supported", path, s)
+		}
+		if key != "" {
+			keyPath := key
+			if strings.Contains(key, ".") {
+				keyPath = "[" + key + "]"
+			}
+			if parentPath != "" {
+				keyPath = parentPath + "." + keyPath
+			}
+			segments = append(segments, pathSegment{
+				key: key, path: keyPath, parentPath: parentPath,
+			})
+			parentPath = keyPath
+		}
+		if index != "" {
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index in field path %s: %w", path, err)
+			}
+			indexPath := parentPath + "[" + index + "]"
+			segments = append(segments, pathSegment{
+				index: i, isIndex: true, path: indexPath, parentPath: parentPath,
+			})
+			parentPath = indexPath
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("empty field path")
+	}
+	return segments, nil
+}
+
+// lookupParent follows all but the last of the segments from node,
+// and returns the node holding the field named by the last one. If
+// create is true, missing maps along the way are created; if not,
+// a nil node is returned when the path doesn't exist, including
+// when a list index is out of range.
+func lookupParent(
+	node *kyaml.RNode, segments []pathSegment, create bool) (*kyaml.RNode, error) {
+	for i, s := range segments[:len(segments)-1] {
+		if !create && s.isIndex && node.YNode().Kind == kyaml.SequenceNode &&
+			s.index >= len(node.Content()) {
+			return nil, nil
+		}
+		child, err := lookupSegment(node, s)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			if !create {
+				return nil, nil
+			}
+			if segments[i+1].isIndex {
+				return nil, fmt.Errorf("%s does not exist", s.path)
+			}
+			child = kyaml.NewMapRNode(nil)
+			if err = node.PipeE(kyaml.SetField(s.key, child)); err != nil {
+				return nil, err
+			}
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// lookupSegment returns the child of node named by the segment, or
+// nil if a map has no such key. It returns an error if node is not
+// a map, or, for an index, a list with an element at the index.
+func lookupSegment(node *kyaml.RNode, s pathSegment) (*kyaml.RNode, error) {
+	if s.isIndex {
+		if node.YNode().Kind != kyaml.SequenceNode {
+			return nil, fmt.Errorf("%s is not a list", s.parentPath)
+		}
+		elements := node.Content()
+		if s.index >= len(elements) {
+			return nil, fmt.Errorf(
+				"index %d out of range for %s with %d elements",
+				s.index, s.parentPath, len(elements))
+		}
+		return kyaml.NewRNode(elements[s.index]), nil
+	}
+	if node.YNode().Kind != kyaml.MappingNode {
+		return nil, fmt.Errorf("%s is not a map", s.parentPath)
+	}
+	field := node.Field(s.key)
+	if field == nil {
+		return nil, nil
+	}
+	return field.Value, nil
+}
+
+// GetFieldValue returns the value of the field at the dot-separated
+// path, like RNode.GetFieldValue, which it calls. A segment like
+// containers[0] indexes into a list; GetFieldValue returns an error
+// saying so if the field isn't a list, or the index is out of range,
+// rather than a NoFieldError.
+func (r *Resource) GetFieldValue(path string) (interface{}, error) {
+	if segments, err := parseFieldPath(path); err == nil {
+		if err = checkIndices(&r.RNode, segments); err != nil {
+			return nil, fmt.Errorf("cannot get %s: %w", path, err)
+		}
+	}
+	return r.RNode.GetFieldValue(path)
+}
+
+// checkIndices follows the segments from node, and returns an
+// error if an index segment is applied to something that isn't a
+// list, or is out of range. It stops at the first missing field, or
+// at a key applied to something that isn't a map, leaving those to
+// RNode.GetFieldValue.
+func checkIndices(node *kyaml.RNode, segments []pathSegment) error {
+	for _, s := range segments {
+		if !s.isIndex && node.YNode().Kind != kyaml.MappingNode {
+			return nil
+		}
+		child, err := lookupSegment(node, s)
+		if err != nil || child == nil {
+			return err
+		}
+		node = child
+	}
+	return nil
+}
+
+// SetFieldValue sets the field at the dot-separated path, e.g.
+// spec.template.spec.containers[0].image, to the value, creating
+// missing maps along the path. It returns an error if the path goes
+// through a field that isn't a map, or a list index out of range.
+func (r *Resource) SetFieldValue(path string, value interface{}) error {
+	segments, err := parseFieldPath(path)
+	if err != nil {
+		return err
+	}
+	v := &kyaml.Node{}
+	if err = v.Encode(value); err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	parent, err := lookupParent(&r.RNode, segments, true)
+	if err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	last := segments[len(segments)-1]
+	// Check that the parent is a map, or a list with the index.
+	if _, err = lookupSegment(parent, last); err != nil {
+		return fmt.Errorf("cannot set %s: %w", path, err)
+	}
+	if last.isIndex {
+		parent.YNode().Content[last.index] = v
+		return nil
+	}
+	return parent.PipeE(kyaml.SetField(last.key, kyaml.NewRNode(v)))
+}
+
+// DeleteFieldValue removes the field at the dot-separated path, e.g.
+// spec.template.spec.nodeSelector, or the list element at an index.
+// It does nothing if the path doesn't exist, and returns an error
+// if the path goes through a field that isn't a map or a list.
+func (r *Resource) DeleteFieldValue(path string) error {
+	segments, err := parseFieldPath(path)
+	if err != nil {
+		return err
+	}
+	parent, err := lookupParent(&r.RNode, segments, false)
+	if err != nil {
+		return fmt.Errorf("cannot delete %s: %w", path, err)
+	}
+	if parent == nil {
+		return nil
+	}
+	last := segments[len(segments)-1]
+	if last.isIndex {
+		if parent.YNode().Kind != kyaml.SequenceNode {
+			return fmt.Errorf("cannot delete %s: %s is not a list", path, last.parentPath)
+		}
+		content := parent.YNode().Content
+		if last.index < len(content) {
+			parent.YNode().Content = append(content[:last.index], content[last.index+1:]...)
+		}
+		return nil
+	}
+	if parent.YNode().Kind != kyaml.MappingNode {
+		return fmt.Errorf("cannot delete %s: %s is not a map", path, last.parentPath)
+	}
+	_, err = parent.Pipe(kyaml.FieldClearer{Name: last.key})
+	return err
+}
+
+// MergeListByKey merges the list of maps at the dot-separated path in
+// other into the one in r. Elements are matched by the value of key:
+// a matched element of r is deep merged with the one from other, the
+// values of other winning, and unmatched elements of other are
+// appended, keeping their order. If r has no list at the path, it
+// gets a copy of the one in other. It returns an error if either
+// field isn't a list of maps.
+func (r *Resource) MergeListByKey(path, key string, other *Resource) error {
+	segments, err := parseFieldPath(path)
+	if err != nil {
+		return err
+	}
+	last := segments[len(segments)-1]
+	src, err := lookupListOfMaps(&other.RNode, segments)
+	if err != nil {
+		return fmt.Errorf("cannot merge %s of %s: %w", path, other.CurId(), err)
+	}
+	if src == nil {
+		return nil
+	}
+	dstParent, err := lookupParent(&r.RNode, segments, true)
+	if err != nil {
+		return fmt.Errorf("cannot merge %s: %w", path, err)
+	}
+	dst, err := lookupListOfMaps(&r.RNode, segments)
+	if err != nil {
+		return fmt.Errorf("cannot merge %s: %w", path, err)
+	}
+	if dst == nil {
+		if last.isIndex {
+			return fmt.Errorf("cannot merge %s: %s does not exist", path, last.path)
+		}
+		return dstParent.PipeE(kyaml.SetField(last.key, src.Copy()))
+	}
+	for _, elem := range src.Content() {
+		if match := findByKey(dst.Content(), key, elem); match != nil {
+			mergeMaps(match, elem)
+			continue
+		}
+		dst.YNode().Content = append(dst.YNode().Content, kyaml.CopyYNode(elem))
+	}
+	return nil
+}
+
+// lookupListOfMaps returns the field named by the segments, or nil
+// if it doesn't exist. It returns an error if the field isn't a list
+// of maps.
+func lookupListOfMaps(node *kyaml.RNode, segments []pathSegment) (*kyaml.RNode, error) {
+	parent, err := lookupParent(node, segments, false)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	last := segments[len(segments)-1]
+	if last.isIndex && parent.YNode().Kind == kyaml.SequenceNode &&
+		last.index >= len(parent.Content()) {
+		return nil, nil
+	}
+	list, err := lookupSegment(parent, last)
+	if err != nil || list == nil {
+		return nil, err
+	}
+	if list.YNode().Kind != kyaml.SequenceNode {
+		return nil, fmt.Errorf("%s is not a list", last.path)
+	}
+	for i, elem := range list.Content() {
+		if elem.Kind != kyaml.MappingNode {
+			return nil, fmt.Errorf("%s[%d] is not a map", last.path, i)
+		}
+	}
+	return list, nil
+}
+
+// findByKey returns the element of list whose value for key is the
+// same scalar as that of elem, or nil if there is none, or elem has
+// no such value.
+func findByKey(list []*kyaml.Node, key string, elem *kyaml.Node) *kyaml.Node {
+	v := kyaml.NewRNode(elem).Field(key)
+	if v == nil || v.Value.YNode().Kind != kyaml.ScalarNode {
+		return nil
+	}
+	for _, candidate := range list {
+		c := kyaml.NewRNode(candidate).Field(key)
+		if c != nil && c.Value.YNode().Kind == kyaml.ScalarNode &&
+			c.Value.YNode().Value == v.Value.YNode().Value {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// mergeMaps sets the fields of src in dst, merging maps found in
+// both recursively and replacing any other value.
+func mergeMaps(dst, src *kyaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		k, v := src.Content[i], src.Content[i+1]
+		j := 0
+		for ; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == k.Value {
+				break
+			}
+		}
+		switch {
+		case j+1 >= len(dst.Content):
+			dst.Content = append(dst.Content, kyaml.CopyYNode(k), kyaml.CopyYNode(v))
+		case dst.Content[j+1].Kind == kyaml.MappingNode && v.Kind == kyaml.MappingNode:
+			mergeMaps(dst.Content[j+1], v)
+		default:
+			dst.Content[j+1] = kyaml.CopyYNode(v)
+		}
+	}
+}
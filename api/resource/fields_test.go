@@ -0,0 +1,267 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fieldsDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+      - name: proxy
+        image: proxy:v1
+`
+
+func TestSetFieldValue(t *testing.T) {
+	r, err := factory.FromBytes([]byte(fieldsDeployment))
+	require.NoError(t, err)
+
+	require.NoError(t, r.SetFieldValue("spec.replicas", 3))
+	require.NoError(t, r.SetFieldValue("spec.template.spec.containers[1].image", "proxy:v2"))
+	require.NoError(t, r.SetFieldValue(
+		"spec.template.spec.nodeSelector.[kubernetes.io/os]", "linux"))
+	require.NoError(t, r.SetFieldValue(
+		"spec.template.spec.containers[0]",
+		map[string]interface{}{"name": "app", "image": "app:v2"}))
+	require.NoError(t, r.SetFieldValue("metadata.labels.enabled", true))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    enabled: true
+  name: app
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - image: app:v2
+        name: app
+      - image: proxy:v2
+        name: proxy
+      nodeSelector:
+        kubernetes.io/os: linux
+`, r.MustYaml())
+	v, err := r.GetFieldValue("spec.replicas")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+}
+
+func TestSetFieldValueErrors(t *testing.T) {
+	r, err := factory.FromBytes([]byte(fieldsDeployment))
+	require.NoError(t, err)
+
+	for path, message := range map[string]string{
+		"spec.replicas.count":                   "cannot set spec.replicas.count: spec.replicas is not a map",
+		"spec.template.spec.containers[2].name": "cannot set spec.template.spec.containers[2].name: index 2 out of range for spec.template.spec.containers with 2 elements",
+		"spec.template[0]":                      "cannot set spec.template[0]: spec.template is not a list",
+		"spec.volumes[0].name":                  "cannot set spec.volumes[0].name: spec.volumes does not exist",
+		"spec.template.spec.containers.name":    "cannot set spec.template.spec.containers.name: spec.template.spec.containers is not a map",
+	} {
+		t.Run(path, func(t *testing.T) {
+			err := r.SetFieldValue(path, "x")
+			require.Error(t, err)
+			assert.Equal(t, message, err.Error())
+		})
+	}
+}
+
+func TestDeleteFieldValue(t *testing.T) {
+	r, err := factory.FromBytes([]byte(fieldsDeployment + `
+      nodeSelector:
+        kubernetes.io/os: linux
+`))
+	require.NoError(t, err)
+
+	require.NoError(t, r.DeleteFieldValue("spec.template.spec.nodeSelector"))
+	require.NoError(t, r.DeleteFieldValue("spec.template.spec.containers[0]"))
+	require.NoError(t, r.DeleteFieldValue("spec.replicas"))
+	// Missing fields are fine.
+	require.NoError(t, r.DeleteFieldValue("spec.paused"))
+	require.NoError(t, r.DeleteFieldValue("spec.strategy.rollingUpdate.maxSurge"))
+	require.NoError(t, r.DeleteFieldValue("spec.template.spec.containers[5]"))
+	require.NoError(t, r.DeleteFieldValue("spec.template.spec.containers[5].image"))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: proxy:v1
+        name: proxy
+`, r.MustYaml())
+
+	err = r.DeleteFieldValue("metadata.name.first")
+	require.Error(t, err)
+	assert.Equal(t,
+		"cannot delete metadata.name.first: metadata.name is not a map", err.Error())
+	err = r.DeleteFieldValue("spec.template.spec.containers.image")
+	require.Error(t, err)
+	assert.Equal(t,
+		"cannot delete spec.template.spec.containers.image: spec.template.spec.containers is not a map",
+		err.Error())
+}
+
+func TestGetFieldValueWithIndices(t *testing.T) {
+	r, err := factory.FromBytes([]byte(fieldsDeployment + `
+      nodeSelector:
+        kubernetes.io/os: linux
+`))
+	require.NoError(t, err)
+
+	for path, expected := range map[string]interface{}{
+		"spec.replicas":                                      1,
+		"spec.template.spec.containers[1].image":             "proxy:v1",
+		"spec.template.spec.containers[0]":                   map[string]interface{}{"name": "app", "image": "app:v1"},
+		"spec.template.spec.nodeSelector.[kubernetes.io/os]": "linux",
+		"spec.template.spec.containers.[name=proxy].image":   "proxy:v1",
+	} {
+		t.Run(path, func(t *testing.T) {
+			v, err := r.GetFieldValue(path)
+			require.NoError(t, err)
+			assert.Equal(t, expected, v)
+		})
+	}
+
+	for path, message := range map[string]string{
+		"spec.template.spec.containers[2].image": "cannot get spec.template.spec.containers[2].image: " +
+			"index 2 out of range for spec.template.spec.containers with 2 elements",
+		"spec.template.spec[0]": "cannot get spec.template.spec[0]: spec.template.spec is not a list",
+		"spec.paused":           "no field named 'spec.paused'",
+		"spec.volumes[0].name":  "no field named 'spec.volumes[0].name'",
+	} {
+		t.Run(path, func(t *testing.T) {
+			_, err := r.GetFieldValue(path)
+			require.Error(t, err)
+			assert.Equal(t, message, err.Error())
+		})
+	}
+}
+
+func TestMergeListByKey(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: example.com/v1
+kind: Firewall
+metadata:
+  name: edge
+spec:
+  rules:
+  - name: ssh
+    port: 22
+    source:
+      cidr: 10.0.0.0/8
+      zone: internal
+  - name: http
+    port: 80
+  - name: https
+    port: 443
+`))
+	require.NoError(t, err)
+	overlay, err := factory.FromBytes([]byte(`
+apiVersion: example.com/v1
+kind: Firewall
+metadata:
+  name: edge
+spec:
+  rules:
+  - name: metrics
+    port: 9090
+  - name: http
+    port: 8080
+    protocol: TCP
+  - name: ssh
+    source:
+      cidr: 192.168.0.0/16
+  - name: dns
+    port: 53
+`))
+	require.NoError(t, err)
+
+	require.NoError(t, r.MergeListByKey("spec.rules", "name", overlay))
+	assert.Equal(t, `apiVersion: example.com/v1
+kind: Firewall
+metadata:
+  name: edge
+spec:
+  rules:
+  - name: ssh
+    port: 22
+    source:
+      cidr: 192.168.0.0/16
+      zone: internal
+  - name: http
+    port: 8080
+    protocol: TCP
+  - name: https
+    port: 443
+  - name: metrics
+    port: 9090
+  - name: dns
+    port: 53
+`, r.MustYaml())
+
+	// Nothing to merge from.
+	require.NoError(t, r.MergeListByKey("spec.egress", "name", overlay))
+	// A missing list is copied.
+	empty, err := factory.FromBytes([]byte(`
+apiVersion: example.com/v1
+kind: Firewall
+metadata:
+  name: edge
+`))
+	require.NoError(t, err)
+	require.NoError(t, empty.MergeListByKey("spec.rules", "name", overlay))
+	rules, err := empty.GetFieldValue("spec.rules")
+	require.NoError(t, err)
+	assert.Len(t, rules, 4)
+}
+
+func TestMergeListByKeyErrors(t *testing.T) {
+	r, err := factory.FromBytes([]byte(fieldsDeployment))
+	require.NoError(t, err)
+	o, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - app
+`))
+	require.NoError(t, err)
+
+	err = r.MergeListByKey("spec.template.spec.containers", "name", o)
+	require.Error(t, err)
+	assert.Equal(t,
+		"cannot merge spec.template.spec.containers of Deployment.v1.apps/app.[noNs]: spec.template.spec.containers[0] is not a map",
+		err.Error())
+	err = o.MergeListByKey("spec.template.spec.containers", "name", r)
+	require.Error(t, err)
+	assert.Equal(t,
+		"cannot merge spec.template.spec.containers: spec.template.spec.containers[0] is not a map",
+		err.Error())
+	err = r.MergeListByKey("spec.replicas", "name", o)
+	require.Error(t, err)
+	assert.Equal(t,
+		"cannot merge spec.replicas of Deployment.v1.apps/app.[noNs]: spec.replicas is not a list",
+		err.Error())
+}
@@ -4,17 +4,25 @@
 package resource
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/filters/patchjson6902"
 	"sigs.k8s.io/kustomize/api/filters/patchstrategicmerge"
 	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/internal/utils"
+	"sigs.k8s.io/kustomize/api/konfig"
 	"sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
 	"sigs.k8s.io/kustomize/kyaml/resid"
+	kyaml_utils "sigs.k8s.io/kustomize/kyaml/utils"
 	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
 	"sigs.k8s.io/yaml"
 )
@@ -26,6 +34,8 @@ type Resource struct {
 	refVarNames []string
 }
 
+const stringDataField = "stringData"
+
 var BuildAnnotations = []string{
 	utils.BuildAnnotationPreviousKinds,
 	utils.BuildAnnotationPreviousNames,
@@ -37,6 +47,9 @@ var BuildAnnotations = []string{
 	utils.BuildAnnotationsRefBy,
 	utils.BuildAnnotationsGenBehavior,
 	utils.BuildAnnotationsGenAddHashSuffix,
+	utils.BuildAnnotationLastTransformer,
+	konfig.NeedsHashAnnotation,
+	konfig.ForceNamespaceAnnotation,
 
 	kioutil.PathAnnotation,
 	kioutil.IndexAnnotation,
@@ -57,6 +70,13 @@ func (r *Resource) GetGvk() resid.Gvk {
 	return resid.GvkFromNode(&r.RNode)
 }
 
+// Hash returns the content hash of the resource computed by h.
+// With the hasher that kustomize uses, a hasher.Hasher, it's the
+// hash that the build appends to the name of a generated ConfigMap
+// or Secret: the generated name is GetName() + "-" + the hash,
+// computed before the suffix is added. ConfigMaps and Secrets are
+// hashed over their kind, name, type and data, encoded with sorted
+// keys, so the hash doesn't depend on the order of the data.
 func (r *Resource) Hash(h ifc.KustHasher) (string, error) {
 	return h.Hash(&r.RNode)
 }
@@ -66,6 +86,159 @@ func (r *Resource) SetGvk(gvk resid.Gvk) {
 	r.SetApiVersion(gvk.ApiVersion())
 }
 
+// SetNamespaceIfUnset sets the namespace of the resource to ns,
+// unless the resource already has one.
+// Returns true if the namespace was set.
+func (r *Resource) SetNamespaceIfUnset(ns string) bool {
+	if r.GetNamespace() != "" {
+		return false
+	}
+	if err := r.SetNamespace(ns); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+// WithLabels returns a deep copy of the resource with the given
+// labels merged into its labels, leaving the resource unchanged.
+func (r *Resource) WithLabels(m map[string]string) *Resource {
+	rc := r.DeepCopy()
+	labels := rc.GetLabels()
+	for k, v := range m {
+		labels[k] = v
+	}
+	if err := rc.SetLabels(labels); err != nil {
+		panic(err)
+	}
+	return rc
+}
+
+// GetAnnotation returns the value of the annotation with the given
+// key, and whether the resource has that annotation.
+func (r *Resource) GetAnnotation(key string) (string, bool) {
+	v, ok := r.GetAnnotations(key)[key]
+	return v, ok
+}
+
+// GetLabel returns the value of the label with the given key,
+// and whether the resource has that label.
+func (r *Resource) GetLabel(key string) (string, bool) {
+	v, ok := r.GetLabels(key)[key]
+	return v, ok
+}
+
+// RemoveAnnotation removes the annotation with the given key, if
+// any, keeping the other annotations. The annotations field is
+// removed if no annotations are left.
+func (r *Resource) RemoveAnnotation(key string) {
+	annotations := r.GetAnnotations()
+	if _, ok := annotations[key]; !ok {
+		return
+	}
+	delete(annotations, key)
+	if err := r.SetAnnotations(annotations); err != nil {
+		panic(err)
+	}
+}
+
+// RemoveLabel removes the label with the given key, if any, keeping
+// the other labels. The labels field is removed if no labels are left.
+func (r *Resource) RemoveLabel(key string) {
+	labels := r.GetLabels()
+	if _, ok := labels[key]; !ok {
+		return
+	}
+	delete(labels, key)
+	if err := r.SetLabels(labels); err != nil {
+		panic(err)
+	}
+}
+
+// EffectiveLabels returns the labels of the resource merged with
+// the labels of its pod template, if it's a workload, i.e. every
+// label that a transformer such as commonLabels has put on the
+// resource or on the pods it makes. On conflict, the resource's
+// own label wins.
+func (r *Resource) EffectiveLabels() map[string]string {
+	path := filtersutil.PodSpecPath(r.GetKind())
+	if path == nil {
+		return r.GetLabels()
+	}
+	template, err := r.Pipe(kyaml.Lookup(path[:len(path)-1]...))
+	if err != nil {
+		panic(err)
+	}
+	if template == nil {
+		return r.GetLabels()
+	}
+	return mergeStringMaps(template.GetLabels(), r.GetLabels())
+}
+
+// EnsureListField creates an empty list at the given dot-separated
+// path, e.g. "spec.template.spec.volumes", if there is nothing there.
+// It returns an error if something other than a list is there.
+func (r *Resource) EnsureListField(path string) error {
+	fields := kyaml_utils.SmarterPathSplitter(path, ".")
+	node, err := r.Pipe(kyaml.Lookup(fields...))
+	if err != nil {
+		return fmt.Errorf("cannot look up %s: %w", path, err)
+	}
+	if node != nil {
+		if node.YNode().Kind != kyaml.SequenceNode {
+			return fmt.Errorf("%s is not a list", path)
+		}
+		return nil
+	}
+	_, err = r.Pipe(kyaml.LookupCreate(kyaml.SequenceNode, fields...))
+	if err != nil {
+		return fmt.Errorf("cannot create list at %s: %w", path, err)
+	}
+	return nil
+}
+
+// IsWorkload returns true if the resource is of one of the
+// built-in kinds that hold a pod spec, e.g. a Deployment.
+func (r *Resource) IsWorkload() bool {
+	return filtersutil.PodSpecPath(r.GetKind()) != nil
+}
+
+// GetReplicas returns the replica count of the resource, and whether
+// it has one. It returns false if the resource's kind can't be
+// scaled, e.g. a Pod, or if the count isn't set, and an error if the
+// count isn't an integer.
+func (r *Resource) GetReplicas() (int64, bool, error) {
+	path := filtersutil.ReplicasPath(r.GetKind())
+	if path == nil {
+		return 0, false, nil
+	}
+	node, err := r.Pipe(kyaml.Lookup(path...))
+	if err != nil || node == nil {
+		return 0, false, err
+	}
+	n, err := strconv.ParseInt(node.YNode().Value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf(
+			"replicas of %s is not an integer: %w", r.CurId(), err)
+	}
+	return n, true, nil
+}
+
+// SetReplicas sets the replica count of the resource. It returns an
+// error if the resource's kind can't be scaled.
+func (r *Resource) SetReplicas(n int64) error {
+	path := filtersutil.ReplicasPath(r.GetKind())
+	if path == nil {
+		return fmt.Errorf("%s has no replica count", r.CurId())
+	}
+	value := kyaml.NewScalarRNode(strconv.FormatInt(n, 10))
+	value.YNode().Tag = kyaml.NodeTagInt
+	return r.SetMapField(value, path...)
+}
+
+// GetOrigin returns where the resource came from, as recorded in the
+// config.kubernetes.io/origin annotation, or nil if it isn't recorded.
+// The annotation is set when the kustomization lists originAnnotations
+// in buildMetadata, and is kept in the build output.
 func (r *Resource) GetOrigin() (*Origin, error) {
 	annotations := r.GetAnnotations()
 	originAnnotations, ok := annotations[utils.OriginAnnotationKey]
@@ -79,6 +252,8 @@ func (r *Resource) GetOrigin() (*Origin, error) {
 	return &origin, nil
 }
 
+// SetOrigin records where the resource came from, or removes the
+// record if origin is nil.
 func (r *Resource) SetOrigin(origin *Origin) error {
 	annotations := r.GetAnnotations()
 	if origin == nil {
@@ -155,11 +330,39 @@ func (r *Resource) DeepCopy() *Resource {
 
 // CopyMergeMetaDataFieldsFrom copies everything but the non-metadata in
 // the resource.
+// Labels and annotations are merged, with the values of r winning
+// on conflict, except for build annotations, where other wins.
+// The name and namespace of other always replace those of r; use
+// CopyMergeMetaDataFieldsKeepingIdFrom to keep them.
 // TODO: move to RNode, use GetMeta to improve performance.
-// TODO: make a version of mergeStringMaps that is build-annotation aware
-//   to avoid repeatedly setting refby and genargs annotations
+// TODO: make a version of mergeStringMaps that is build-annotation aware to avoid repeatedly setting refby and genargs annotations
 // Must remove the kustomize bit at the end.
 func (r *Resource) CopyMergeMetaDataFieldsFrom(other *Resource) error {
+	if err := r.copyMergeLabelsAndAnnotationsFrom(other); err != nil {
+		return err
+	}
+	if err := r.SetName(other.GetName()); err != nil {
+		return fmt.Errorf("copyMerge cannot set name - %w", err)
+	}
+	if err := r.SetNamespace(other.GetNamespace()); err != nil {
+		return fmt.Errorf("copyMerge cannot set namespace - %w", err)
+	}
+	r.copyKustomizeSpecificFields(other)
+	return nil
+}
+
+// CopyMergeMetaDataFieldsKeepingIdFrom is like
+// CopyMergeMetaDataFieldsFrom, but keeps the name and
+// namespace of r.
+func (r *Resource) CopyMergeMetaDataFieldsKeepingIdFrom(other *Resource) error {
+	if err := r.copyMergeLabelsAndAnnotationsFrom(other); err != nil {
+		return err
+	}
+	r.copyKustomizeSpecificFields(other)
+	return nil
+}
+
+func (r *Resource) copyMergeLabelsAndAnnotationsFrom(other *Resource) error {
 	if err := r.SetLabels(
 		mergeStringMaps(other.GetLabels(), r.GetLabels())); err != nil {
 		return fmt.Errorf("copyMerge cannot set labels - %w", err)
@@ -174,14 +377,6 @@ func (r *Resource) CopyMergeMetaDataFieldsFrom(other *Resource) error {
 	if err := r.SetAnnotations(merged); err != nil {
 		return fmt.Errorf("copyMerge cannot set annotations - %w", err)
 	}
-
-	if err := r.SetName(other.GetName()); err != nil {
-		return fmt.Errorf("copyMerge cannot set name - %w", err)
-	}
-	if err := r.SetNamespace(other.GetNamespace()); err != nil {
-		return fmt.Errorf("copyMerge cannot set namespace - %w", err)
-	}
-	r.copyKustomizeSpecificFields(other)
 	return nil
 }
 
@@ -197,6 +392,89 @@ func (r *Resource) MergeBinaryDataMapFrom(o *Resource) {
 	r.SetBinaryDataMap(mergeStringMaps(o.GetBinaryDataMap(), r.GetBinaryDataMap()))
 }
 
+// MergeStringDataMapFrom merges the stringData map of the Secret o
+// into r. The keys r sets in data or stringData keep r's value; a
+// key of o's stringData that r sets in data is dropped, since the
+// API server lets stringData override data.
+func (r *Resource) MergeStringDataMapFrom(o *Resource) {
+	base := o.getStringDataMap()
+	for k := range r.GetDataMap() {
+		delete(base, k)
+	}
+	r.setStringDataMap(mergeStringMaps(base, r.getStringDataMap()))
+}
+
+func (r *Resource) getStringDataMap() map[string]string {
+	result := map[string]string{}
+	node, err := r.Pipe(kyaml.Lookup(stringDataField))
+	if err != nil || node == nil {
+		return result
+	}
+	_ = node.VisitFields(func(n *kyaml.MapNode) error {
+		result[kyaml.GetValue(n.Key)] = kyaml.GetValue(n.Value)
+		return nil
+	})
+	return result
+}
+
+func (r *Resource) setStringDataMap(m map[string]string) {
+	if err := r.PipeE(kyaml.Clear(stringDataField)); err != nil {
+		panic(err)
+	}
+	if len(m) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	node := kyaml.NewMapRNode(nil)
+	for _, k := range keys {
+		if err := node.PipeE(
+			kyaml.SetField(k, kyaml.NewStringRNode(m[k]))); err != nil {
+			panic(err)
+		}
+	}
+	if err := r.PipeE(kyaml.SetField(stringDataField, node)); err != nil {
+		panic(err)
+	}
+}
+
+// MergeStrict merges the data and binaryData maps of o into r like
+// MergeDataMapFrom and MergeBinaryDataMapFrom, but returns an error
+// rather than keeping r's value when o and r have different values
+// for the same key. r is unchanged if an error is returned.
+func (r *Resource) MergeStrict(o *Resource) error {
+	if err := checkMapConflicts("data", o.GetDataMap(), r.GetDataMap()); err != nil {
+		return fmt.Errorf("cannot merge %s into %s: %w", o.CurId(), r.CurId(), err)
+	}
+	if err := checkMapConflicts(
+		"binaryData", o.GetBinaryDataMap(), r.GetBinaryDataMap()); err != nil {
+		return fmt.Errorf("cannot merge %s into %s: %w", o.CurId(), r.CurId(), err)
+	}
+	r.MergeDataMapFrom(o)
+	r.MergeBinaryDataMapFrom(o)
+	return nil
+}
+
+// checkMapConflicts returns an error naming the first key, in sorted
+// order, that has different values in base and overlay.
+func checkMapConflicts(field string, base, overlay map[string]string) error {
+	keys := make([]string, 0, len(overlay))
+	for k := range overlay {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if v, ok := base[k]; ok && v != overlay[k] {
+			return fmt.Errorf(
+				"conflicting values for %s key %q: %q and %q", field, k, v, overlay[k])
+		}
+	}
+	return nil
+}
+
 func (r *Resource) ErrIfNotEquals(o *Resource) error {
 	meYaml, err := r.AsYAML()
 	if err != nil {
@@ -297,7 +575,9 @@ func (r *Resource) PrefixesSuffixesEquals(o ResCtx) bool {
 
 // RemoveBuildAnnotations removes annotations created by the build process.
 // These are internal-only to kustomize, added to the data pipeline to
-// track name changes so name references can be fixed.
+// track name changes so name references can be fixed. They are
+// listed in BuildAnnotations; other annotations are kept. If no
+// annotations are left, the annotations field is removed.
 func (r *Resource) RemoveBuildAnnotations() {
 	annotations := r.GetAnnotations()
 	if len(annotations) == 0 {
@@ -339,8 +619,7 @@ func (r *Resource) KindChangeAllowed() bool {
 }
 
 func (r *Resource) isEnabled(annoKey string) bool {
-	annotations := r.GetAnnotations()
-	v, ok := annotations[annoKey]
+	v, ok := r.GetAnnotation(annoKey)
 	return ok && v == utils.Enabled
 }
 
@@ -371,6 +650,102 @@ func (r *Resource) AsYAML() ([]byte, error) {
 	return yaml.JSONToYAML(json)
 }
 
+// AsYAMLPreservingComments returns the resource in Yaml form like
+// AsYAML, but written straight from the yaml node, so the comments
+// and the field order of the source are kept.
+func (r *Resource) AsYAMLPreservingComments() ([]byte, error) {
+	s, err := r.RNode.String()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// AsYAMLWithSeparator returns the output of AsYAML preceded by a
+// document separator and ending in exactly one newline, so that
+// the outputs of many resources can be concatenated into a
+// multi-document stream.
+func (r *Resource) AsYAMLWithSeparator() ([]byte, error) {
+	y, err := r.AsYAML()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("---\n"), append(bytes.TrimRight(y, "\n"), '\n')...), nil
+}
+
+// ToUnstructuredBytes returns the resource as JSON, minus the
+// annotations kustomize uses internally, in the form accepted by
+// the UnmarshalJSON method of apimachinery's Unstructured type.
+// Use Factory.FromUnstructuredBytes to convert it back.
+func (r *Resource) ToUnstructuredBytes() ([]byte, error) {
+	c := r.DeepCopy()
+	c.RemoveBuildAnnotations()
+	return c.MarshalJSON()
+}
+
+// ContentHash returns the hex form of the sha256 of the output
+// of ToUnstructuredBytes. It changes whenever any field of the
+// resource, other than kustomize's internal annotations, changes.
+func (r *Resource) ContentHash() (string, error) {
+	bs, err := r.ToUnstructuredBytes()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(bs)), nil
+}
+
+// CanonicalYAML returns the resource as YAML, minus the annotations
+// kustomize uses internally, with apiVersion, kind and metadata
+// first, status last and all other fields sorted by name. Equivalent
+// resources give the same output whatever the order of their fields,
+// which makes it suitable for golden files.
+func (r *Resource) CanonicalYAML() ([]byte, error) {
+	j, err := r.ToUnstructuredBytes()
+	if err != nil {
+		return nil, err
+	}
+	y, err := yaml.JSONToYAML(j)
+	if err != nil {
+		return nil, err
+	}
+	node, err := kyaml.Parse(string(y))
+	if err != nil {
+		return nil, err
+	}
+	content := node.YNode().Content
+	fields := make([][2]*kyaml.Node, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		fields = append(fields, [2]*kyaml.Node{content[i], content[i+1]})
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return topLevelFieldRank(fields[i][0].Value) < topLevelFieldRank(fields[j][0].Value)
+	})
+	content = content[:0]
+	for _, f := range fields {
+		content = append(content, f[0], f[1])
+	}
+	node.YNode().Content = content
+	s, err := node.String()
+	return []byte(s), err
+}
+
+// topLevelFieldRank orders the top-level fields of a resource.
+// Fields of the same rank keep their (sorted) order.
+func topLevelFieldRank(field string) int {
+	switch field {
+	case kyaml.APIVersionField:
+		return 0
+	case kyaml.KindField:
+		return 1
+	case kyaml.MetadataField:
+		return 2
+	case "status":
+		return 4
+	default:
+		return 3
+	}
+}
+
 // MustYaml returns YAML or panics.
 func (r *Resource) MustYaml() string {
 	yml, err := r.AsYAML()
@@ -382,8 +757,7 @@ func (r *Resource) MustYaml() string {
 
 // Behavior returns the behavior for the resource.
 func (r *Resource) Behavior() types.GenerationBehavior {
-	annotations := r.GetAnnotations()
-	if v, ok := annotations[utils.BuildAnnotationsGenBehavior]; ok {
+	if v, ok := r.GetAnnotation(utils.BuildAnnotationsGenBehavior); ok {
 		return types.NewGenerationBehavior(v)
 	}
 	return types.NewGenerationBehavior("")
@@ -398,9 +772,34 @@ func (r *Resource) SetBehavior(behavior types.GenerationBehavior) {
 	}
 }
 
+// GetLastTransformer returns the name of the transformer that
+// last modified the resource, or "" if none was recorded.
+func (r *Resource) GetLastTransformer() string {
+	v, _ := r.GetAnnotation(utils.BuildAnnotationLastTransformer)
+	return v
+}
+
+// SetLastTransformer records the name of the transformer that
+// last modified the resource. The record is a build annotation,
+// so it is removed from the build output.
+func (r *Resource) SetLastTransformer(name string) {
+	annotations := r.GetAnnotations()
+	annotations[utils.BuildAnnotationLastTransformer] = name
+	if err := r.SetAnnotations(annotations); err != nil {
+		panic(err)
+	}
+}
+
 // NeedHashSuffix returns true if a resource content
 // hash should be appended to the name of the resource.
+// The konfig.NeedsHashAnnotation set to "false" overrides
+// the generator options.
 func (r *Resource) NeedHashSuffix() bool {
+	if v, ok := r.GetAnnotation(konfig.NeedsHashAnnotation); ok {
+		if b, err := strconv.ParseBool(v); err == nil && !b {
+			return false
+		}
+	}
 	return r.isEnabled(utils.BuildAnnotationsGenAddHashSuffix)
 }
 
@@ -461,8 +860,13 @@ func (r *Resource) GetRefBy() []resid.ResId {
 
 // AppendRefBy appends a ResId into the refBy list
 // Using any type except fmt.Stringer here results in a compilation error
+// An id that is already in the list isn't appended again.
 func (r *Resource) AppendRefBy(id fmt.Stringer) {
-	r.appendCsvAnnotation(utils.BuildAnnotationsRefBy, id.String())
+	s := id.String()
+	if utils.StringSliceContains(r.getCsvAnnotation(utils.BuildAnnotationsRefBy), s) {
+		return
+	}
+	r.appendCsvAnnotation(utils.BuildAnnotationsRefBy, s)
 }
 
 // GetRefVarNames returns vars that refer to current resource
@@ -470,8 +874,12 @@ func (r *Resource) GetRefVarNames() []string {
 	return r.refVarNames
 }
 
-// AppendRefVarName appends a name of a var into the refVar list
+// AppendRefVarName appends a name of a var into the refVar list,
+// unless it's already there.
 func (r *Resource) AppendRefVarName(variable types.Var) {
+	if utils.StringSliceContains(r.refVarNames, variable.Name) {
+		return
+	}
 	r.refVarNames = append(r.refVarNames, variable.Name)
 }
 
@@ -499,6 +907,80 @@ func (r *Resource) ApplySmPatch(patch *Resource) error {
 	return nil
 }
 
+// ApplyStrategicMergePatch applies the given strategic merge patch,
+// in YAML or JSON form, to the resource. Lists are merged using the
+// patch strategies of the resource's kind, e.g. the containers of a
+// Deployment are merged by name; for kinds without known strategies,
+// e.g. most CRDs, lists are replaced as with a JSON merge patch.
+// The patch needn't have an apiVersion, kind or name, and can't
+// change the id of the resource.
+func (r *Resource) ApplyStrategicMergePatch(patch []byte) error {
+	node, err := kyaml.Parse(string(patch))
+	if err != nil {
+		return fmt.Errorf("invalid strategic merge patch: %w", err)
+	}
+	if node.YNode().Kind != kyaml.MappingNode {
+		return fmt.Errorf("invalid strategic merge patch: not a map")
+	}
+	if node.GetApiVersion() == "" {
+		node.SetApiVersion(r.GetApiVersion())
+	}
+	if node.GetKind() == "" {
+		node.SetKind(r.GetKind())
+	}
+	if node.GetName() == "" {
+		if err = node.SetName(r.GetName()); err != nil {
+			return err
+		}
+	}
+	if err = r.ApplySmPatch(&Resource{RNode: *node}); err != nil {
+		return fmt.Errorf("cannot patch %s: %w", r.CurId(), err)
+	}
+	return nil
+}
+
+// ApplyJSON6902Patch applies the given RFC 6902 JSON patch, an
+// array of operations in JSON or YAML form, to the resource. The
+// patch is applied atomically: if an operation fails, e.g. a test
+// operation or the removal of a missing path, the resource is left
+// unchanged.
+func (r *Resource) ApplyJSON6902Patch(ops []byte) error {
+	// The filter is called directly rather than through ApplyFilter,
+	// which would clear the resource if the patch failed.
+	f := patchjson6902.Filter{Patch: string(ops)}
+	if _, err := f.Filter([]*kyaml.RNode{&r.RNode}); err != nil {
+		return fmt.Errorf("cannot patch %s: %w", r.CurId(), err)
+	}
+	return nil
+}
+
+// MergeSpecFrom deep-merges the spec of o into the spec of r,
+// using the strategic merge keys of r's kind where they're known.
+// Everything else in o, e.g. its metadata, is ignored.
+func (r *Resource) MergeSpecFrom(o *Resource) error {
+	spec, err := o.Pipe(kyaml.Lookup("spec"))
+	if err != nil {
+		return fmt.Errorf("cannot look up spec of %s: %w", o.CurId(), err)
+	}
+	if kyaml.IsMissingOrNull(spec) {
+		return nil
+	}
+	patch := kyaml.NewMapRNode(nil)
+	patch.SetApiVersion(r.GetApiVersion())
+	patch.SetKind(r.GetKind())
+	if err = patch.SetName(r.GetName()); err != nil {
+		return err
+	}
+	if err = patch.PipeE(kyaml.SetField("spec", spec.Copy())); err != nil {
+		return err
+	}
+	if err = r.ApplyFilter(patchstrategicmerge.Filter{Patch: patch}); err != nil {
+		return fmt.Errorf(
+			"cannot merge spec of %s into %s: %w", o.CurId(), r.CurId(), err)
+	}
+	return nil
+}
+
 func (r *Resource) ApplyFilter(f kio.Filter) error {
 	l, err := f.Filter([]*kyaml.RNode{&r.RNode})
 	if len(l) == 0 {
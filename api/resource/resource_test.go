@@ -4,12 +4,16 @@
 package resource_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/kustomize/api/internal/utils"
+	"sigs.k8s.io/kustomize/api/konfig"
 	"sigs.k8s.io/kustomize/api/provider"
 	. "sigs.k8s.io/kustomize/api/resource"
 	"sigs.k8s.io/kustomize/api/types"
@@ -77,6 +81,124 @@ func TestResourceString(t *testing.T) {
 	}
 }
 
+func TestResourceStringIsValidJSON(t *testing.T) {
+	r := testDeployment.DeepCopy()
+	r.SetBehavior(types.BehaviorMerge)
+	r.AddNamePrefix("p-")
+	r.AppendRefVarName(types.Var{Name: "SERVICE"})
+	var obj map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(r.String()), &obj))
+	assert.Equal(t, "Deployment", obj["kind"])
+}
+
+func TestPrefixesSuffixesEquals(t *testing.T) {
+	withAffixes := func(prefixes, suffixes []string) *Resource {
+		r := testConfigMap.DeepCopy()
+		for _, p := range prefixes {
+			r.AddNamePrefix(p)
+		}
+		for _, s := range suffixes {
+			r.AddNameSuffix(s)
+		}
+		return r
+	}
+	for name, tc := range map[string]struct {
+		r, o  *Resource
+		equal bool
+	}{
+		"same separators": {
+			r:     withAffixes([]string{"dev-"}, []string{"-v1"}),
+			o:     withAffixes([]string{"dev-"}, []string{"-v1"}),
+			equal: true,
+		},
+		"prefix separators differ": {
+			r: withAffixes([]string{"dev-"}, nil),
+			o: withAffixes([]string{"dev_"}, nil),
+		},
+		"suffix separators differ": {
+			r: withAffixes(nil, []string{"-v1"}),
+			o: withAffixes(nil, []string{".v1"}),
+		},
+		"separator only on one side": {
+			r: withAffixes([]string{"dev"}, nil),
+			o: withAffixes([]string{"dev-"}, nil),
+		},
+		"outer prefixes match": {
+			r:     withAffixes([]string{"app-", "dev-"}, nil),
+			o:     withAffixes([]string{"dev-"}, nil),
+			equal: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.equal, tc.r.PrefixesSuffixesEquals(tc.o))
+		})
+	}
+}
+
+func TestToUnstructuredBytes(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: winnie
+  annotations:
+    owner: pooh
+    internal.config.kubernetes.io/previousNames: piglet
+    internal.config.kubernetes.io/previousKinds: ConfigMap
+    internal.config.kubernetes.io/previousNamespaces: default
+data:
+  honey: "3"
+`))
+	require.NoError(t, err)
+	bs, err := r.ToUnstructuredBytes()
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"apiVersion":"v1","data":{"honey":"3"},"kind":"ConfigMap",`+
+			`"metadata":{"annotations":{"owner":"pooh"},"name":"winnie"}}`,
+		string(bs))
+	// The receiver keeps its build annotations.
+	assert.Len(t, r.PrevIds(), 1)
+
+	// Round trip through the generic object form used by
+	// apimachinery's Unstructured type.
+	var obj map[string]interface{}
+	require.NoError(t, json.Unmarshal(bs, &obj))
+	bs, err = json.Marshal(obj)
+	require.NoError(t, err)
+	back, err := factory.FromUnstructuredBytes(bs)
+	require.NoError(t, err)
+	assert.Equal(t, "winnie", back.GetName())
+	assert.Equal(t, map[string]string{"owner": "pooh"}, back.GetAnnotations())
+	assert.Equal(t, map[string]string{"honey": "3"}, back.GetDataMap())
+	assert.Empty(t, back.PrevIds())
+	bs2, err := back.ToUnstructuredBytes()
+	require.NoError(t, err)
+	assert.Equal(t, string(bs), string(bs2))
+
+	_, err = factory.FromUnstructuredBytes([]byte(`[1, 2]`))
+	assert.Error(t, err)
+	_, err = factory.FromUnstructuredBytes([]byte(`null`))
+	assert.Error(t, err)
+}
+
+func TestContentHash(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	h1, err := r.ContentHash()
+	require.NoError(t, err)
+	assert.Len(t, h1, 64)
+
+	// Internal annotations don't affect the hash.
+	r.StorePreviousId()
+	h2, err := r.ContentHash()
+	require.NoError(t, err)
+	assert.Equal(t, h1, h2)
+
+	r.SetDataMap(map[string]string{"a": "b"})
+	h3, err := r.ContentHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h3)
+}
+
 func TestResourceId(t *testing.T) {
 	tests := []struct {
 		in *Resource
@@ -126,6 +248,85 @@ func TestDeepCopy(t *testing.T) {
 	}
 }
 
+func TestDeepCopyIsIndependent(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	r.SetBehavior(types.BehaviorMerge)
+	r.AppendRefVarName(types.Var{Name: "ONE"})
+
+	cr := r.DeepCopy()
+	cr.SetBehavior(types.BehaviorReplace)
+	cr.EnableHashSuffix()
+	cr.AppendRefVarName(types.Var{Name: "TWO"})
+
+	assert.Equal(t, types.BehaviorMerge, r.Behavior())
+	assert.False(t, r.NeedHashSuffix())
+	assert.Equal(t, []string{"ONE"}, r.GetRefVarNames())
+	assert.Equal(t, types.BehaviorReplace, cr.Behavior())
+	assert.True(t, cr.NeedHashSuffix())
+	assert.Equal(t, []string{"ONE", "TWO"}, cr.GetRefVarNames())
+}
+
+func TestNeedHashSuffixAnnotation(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	r.EnableHashSuffix()
+	assert.True(t, r.NeedHashSuffix())
+	require.NoError(t, r.SetAnnotations(map[string]string{
+		konfig.NeedsHashAnnotation:             "true",
+		utils.BuildAnnotationsGenAddHashSuffix: utils.Enabled,
+	}))
+	assert.True(t, r.NeedHashSuffix())
+	require.NoError(t, r.SetAnnotations(map[string]string{
+		konfig.NeedsHashAnnotation:             "false",
+		utils.BuildAnnotationsGenAddHashSuffix: utils.Enabled,
+	}))
+	assert.False(t, r.NeedHashSuffix())
+}
+
+func TestCopyMergeMetaDataFieldsFrom(t *testing.T) {
+	old, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: base-config
+  namespace: base
+  labels:
+    app: honey
+    tier: pot
+data:
+  a: one
+`))
+	require.NoError(t, err)
+	makeNew := func() *Resource {
+		r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: overlay-config
+  namespace: overlay
+  labels:
+    tier: jar
+data:
+  b: two
+`))
+		require.NoError(t, err)
+		return r
+	}
+
+	r := makeNew()
+	require.NoError(t, r.CopyMergeMetaDataFieldsFrom(old))
+	assert.Equal(t, "base-config", r.GetName())
+	assert.Equal(t, "base", r.GetNamespace())
+	assert.Equal(t, map[string]string{"app": "honey", "tier": "jar"}, r.GetLabels())
+	assert.Equal(t, map[string]string{"b": "two"}, r.GetDataMap())
+
+	r = makeNew()
+	require.NoError(t, r.CopyMergeMetaDataFieldsKeepingIdFrom(old))
+	assert.Equal(t, "overlay-config", r.GetName())
+	assert.Equal(t, "overlay", r.GetNamespace())
+	assert.Equal(t, map[string]string{"app": "honey", "tier": "jar"}, r.GetLabels())
+	assert.Equal(t, map[string]string{"b": "two"}, r.GetDataMap())
+}
+
 func TestApplySmPatch_1(t *testing.T) {
 	resource, err := factory.FromBytes([]byte(`
 apiVersion: apps/v1
@@ -577,6 +778,106 @@ metadata:
 `, string(bytes))
 }
 
+func TestMergeBinaryDataMapFrom(t *testing.T) {
+	testCases := map[string]struct {
+		base     string
+		overlay  string
+		expected string
+	}{
+		"base only": {
+			base: `
+binaryData:
+  logo: aGVsbG8=
+`,
+			overlay: `
+data:
+  mode: prod
+`,
+			expected: `apiVersion: v1
+binaryData:
+  logo: aGVsbG8=
+data:
+  mode: prod
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+		},
+		"overlay only": {
+			base: `
+data:
+  mode: dev
+`,
+			overlay: `
+binaryData:
+  logo: aGVsbG8=
+`,
+			expected: `apiVersion: v1
+binaryData:
+  logo: aGVsbG8=
+data:
+  mode: dev
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+		},
+		"both": {
+			base: `
+binaryData:
+  logo: aGVsbG8=
+  icon: aWNvbg==
+`,
+			overlay: `
+binaryData:
+  logo: d29ybGQ=
+`,
+			expected: `apiVersion: v1
+binaryData:
+  icon: aWNvbg==
+  logo: d29ybGQ=
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+		},
+		"neither": {
+			base: `
+data:
+  mode: dev
+`,
+			overlay: `
+data:
+  mode: prod
+`,
+			expected: `apiVersion: v1
+data:
+  mode: prod
+kind: ConfigMap
+metadata:
+  name: cm
+`,
+		},
+	}
+	header := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			base, err := factory.FromBytes([]byte(header + tc.base))
+			require.NoError(t, err)
+			overlay, err := factory.FromBytes([]byte(header + tc.overlay))
+			require.NoError(t, err)
+			overlay.MergeDataMapFrom(base)
+			overlay.MergeBinaryDataMapFrom(base)
+			assert.Equal(t, tc.expected, overlay.MustYaml())
+		})
+	}
+}
+
 func TestApplySmPatch_SwapOrder(t *testing.T) {
 	s1 := `
 apiVersion: example.com/v1
@@ -1391,6 +1692,278 @@ spec:
 	}
 }
 
+func TestSetNamespaceIfUnset(t *testing.T) {
+	r := testDeployment.DeepCopy()
+	assert.True(t, r.SetNamespaceIfUnset("prod"))
+	assert.Equal(t, "prod", r.GetNamespace())
+
+	r = testConfigMap.DeepCopy()
+	assert.False(t, r.SetNamespaceIfUnset("prod"))
+	assert.Equal(t, "hundred-acre-wood", r.GetNamespace())
+}
+
+func TestWithLabels(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	require.NoError(t, r.SetLabels(map[string]string{"app": "honey", "tier": "pot"}))
+	rc := r.WithLabels(map[string]string{"tier": "jar", "env": "prod"})
+	assert.Equal(t, map[string]string{"app": "honey", "tier": "pot"}, r.GetLabels())
+	assert.Equal(t,
+		map[string]string{"app": "honey", "tier": "jar", "env": "prod"},
+		rc.GetLabels())
+	assert.Equal(t, r.CurId(), rc.CurId())
+}
+
+func TestGetAnnotationAndGetLabel(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	v, ok := r.GetAnnotation("owner")
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+	v, ok = r.GetLabel("app")
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+
+	require.NoError(t, r.SetAnnotations(map[string]string{"owner": "team-a", "empty": ""}))
+	require.NoError(t, r.SetLabels(map[string]string{"app": "honey"}))
+	v, ok = r.GetAnnotation("owner")
+	assert.True(t, ok)
+	assert.Equal(t, "team-a", v)
+	v, ok = r.GetAnnotation("empty")
+	assert.True(t, ok)
+	assert.Equal(t, "", v)
+	_, ok = r.GetAnnotation("app")
+	assert.False(t, ok)
+	v, ok = r.GetLabel("app")
+	assert.True(t, ok)
+	assert.Equal(t, "honey", v)
+}
+
+func TestRemoveAnnotationAndRemoveLabel(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	// Nothing to remove.
+	r.RemoveAnnotation("owner")
+	r.RemoveLabel("app")
+	assert.Equal(t, testConfigMap.MustString(), r.MustString())
+
+	require.NoError(t, r.SetAnnotations(map[string]string{"owner": "team-a", "tier": "pot"}))
+	require.NoError(t, r.SetLabels(map[string]string{"app": "honey"}))
+	r.RemoveAnnotation("owner")
+	r.RemoveAnnotation("missing")
+	r.RemoveLabel("missing")
+	assert.Equal(t, map[string]string{"tier": "pot"}, r.GetAnnotations())
+	assert.Equal(t, map[string]string{"app": "honey"}, r.GetLabels())
+
+	r.RemoveAnnotation("tier")
+	r.RemoveLabel("app")
+	assert.Empty(t, r.GetAnnotations())
+	assert.Empty(t, r.GetLabels())
+	assert.Equal(t, testConfigMap.MustString(), r.MustString())
+}
+
+func TestGetAnnotationsAndGetLabelsReturnCopies(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	require.NoError(t, r.SetAnnotations(map[string]string{
+		"owner":                     "team-a",
+		utils.BuildAnnotationsRefBy: "Deployment.v1.apps/app.[noNs]",
+	}))
+	require.NoError(t, r.SetLabels(map[string]string{"app": "honey"}))
+
+	annotations := r.GetAnnotations()
+	delete(annotations, utils.BuildAnnotationsRefBy)
+	annotations["owner"] = "team-b"
+	labels := r.GetLabels()
+	labels["app"] = "jar"
+	labels["env"] = "prod"
+
+	assert.Equal(t, map[string]string{
+		"owner":                     "team-a",
+		utils.BuildAnnotationsRefBy: "Deployment.v1.apps/app.[noNs]",
+	}, r.GetAnnotations())
+	assert.Equal(t, map[string]string{"app": "honey"}, r.GetLabels())
+}
+
+func TestEnsureListField(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+  restartPolicy: Never
+`))
+	require.NoError(t, err)
+
+	require.NoError(t, r.EnsureListField("spec.volumes"))
+	require.NoError(t, r.EnsureListField("spec.containers"))
+	assert.Equal(t, `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+spec:
+  containers:
+  - name: app
+  restartPolicy: Never
+  volumes: []
+`, r.MustString())
+
+	err = r.EnsureListField("spec.restartPolicy")
+	require.Error(t, err)
+	assert.Equal(t, "spec.restartPolicy is not a list", err.Error())
+}
+
+func TestCanonicalYAML(t *testing.T) {
+	r1, err := factory.FromBytes([]byte(`
+status:
+  ready: true
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+  replicas: 2
+metadata:
+  name: web
+  labels:
+    tier: web
+    app: shop
+kind: Deployment
+apiVersion: apps/v1
+`))
+	require.NoError(t, err)
+	r2, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: shop
+    tier: web
+  name: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - image: app
+        name: app
+status:
+  ready: true
+`))
+	require.NoError(t, err)
+
+	y1, err := r1.CanonicalYAML()
+	require.NoError(t, err)
+	y2, err := r2.CanonicalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, string(y1), string(y2))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: shop
+    tier: web
+  name: web
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - image: app
+        name: app
+status:
+  ready: true
+`, string(y1))
+}
+
+func TestGetAndSetReplicas(t *testing.T) {
+	for _, kind := range []string{
+		"Deployment", "ReplicaSet", "ReplicationController", "StatefulSet",
+	} {
+		t.Run(kind, func(t *testing.T) {
+			r := factory.FromMap(map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       kind,
+				"metadata": map[string]interface{}{
+					"name": "x",
+				},
+			})
+			_, ok, err := r.GetReplicas()
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			require.NoError(t, r.SetReplicas(3))
+			n, ok, err := r.GetReplicas()
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, int64(3), n)
+			assert.Equal(t, `apiVersion: apps/v1
+kind: `+kind+`
+metadata:
+  name: x
+spec:
+  replicas: 3
+`, r.MustYaml())
+		})
+	}
+}
+
+func TestGetAndSetReplicasUnscalable(t *testing.T) {
+	r := factory.FromMap(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "x",
+		},
+		"spec": map[string]interface{}{
+			"replicas": 2,
+		},
+	})
+	n, ok, err := r.GetReplicas()
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int64(0), n)
+	assert.EqualError(t, r.SetReplicas(3), "Pod.v1.[noGrp]/x.[noNs] has no replica count")
+
+	d := factory.FromMap(map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name": "x",
+		},
+		"spec": map[string]interface{}{
+			"replicas": "many",
+		},
+	})
+	_, _, err = d.GetReplicas()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "replicas of Deployment.v1.apps/x.[noNs] is not an integer")
+}
+
+func TestIsWorkload(t *testing.T) {
+	for kind, expected := range map[string]bool{
+		"CronJob":               true,
+		"DaemonSet":             true,
+		"Deployment":            true,
+		"Job":                   true,
+		"Pod":                   true,
+		"ReplicaSet":            true,
+		"ReplicationController": true,
+		"StatefulSet":           true,
+		"ConfigMap":             false,
+		"Service":               false,
+	} {
+		r := factory.FromMap(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": "x",
+			},
+		})
+		assert.Equal(t, expected, r.IsWorkload(), kind)
+	}
+}
+
 func TestRefBy(t *testing.T) {
 	r, err := factory.FromBytes([]byte(`
 apiVersion: v1
@@ -1429,6 +2002,62 @@ spec:
 	}, r.GetRefBy())
 }
 
+func TestAppendRefByIgnoresDuplicates(t *testing.T) {
+	r := testDeployment.DeepCopy()
+	r.AppendRefBy(resid.FromString("knd1.ver1.gr1/name1.ns1"))
+	r.AppendRefBy(resid.FromString("knd2.ver2.gr2/name2.ns2"))
+	r.AppendRefBy(resid.FromString("knd1.ver1.gr1/name1.ns1"))
+	assert.Equal(t, []resid.ResId{
+		resid.FromString("knd1.ver1.gr1/name1.ns1"),
+		resid.FromString("knd2.ver2.gr2/name2.ns2"),
+	}, r.GetRefBy())
+}
+
+func TestReferencesEqualWithDuplicates(t *testing.T) {
+	withRefBy := func(refBy string) *Resource {
+		r := testDeployment.DeepCopy()
+		require.NoError(t, r.SetAnnotations(
+			map[string]string{utils.BuildAnnotationsRefBy: refBy}))
+		return r
+	}
+	a, b, c := "k.v.g/a.ns", "k.v.g/b.ns", "k.v.g/c.ns"
+	tests := map[string]struct {
+		self, other string
+		equal       bool
+	}{
+		"same refs, different duplicate counts": {
+			self:  a + "," + a + "," + b,
+			other: b + "," + a + "," + b + "," + b,
+			equal: true,
+		},
+		"duplicates hide a missing ref": {
+			self:  a + "," + a,
+			other: a + "," + b,
+			equal: false,
+		},
+		"duplicates on both sides, different refs": {
+			self:  a + "," + b + "," + b,
+			other: a + "," + a + "," + c,
+			equal: false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			self, other := withRefBy(tc.self), withRefBy(tc.other)
+			assert.Equal(t, tc.equal, self.ReferencesEqual(other))
+			assert.Equal(t, tc.equal, other.ReferencesEqual(self))
+		})
+	}
+}
+
+func TestAppendRefVarNameIgnoresDuplicates(t *testing.T) {
+	r := testDeployment.DeepCopy()
+	r.AppendRefVarName(types.Var{Name: "SERVICE"})
+	r.AppendRefVarName(types.Var{Name: "PORT"})
+	r.AppendRefVarName(types.Var{Name: "SERVICE"})
+	assert.Equal(t, []string{"SERVICE", "PORT"}, r.GetRefVarNames())
+}
+
 func TestOrigin(t *testing.T) {
 	r, err := factory.FromBytes([]byte(`
 apiVersion: v1
@@ -1462,6 +2091,31 @@ spec:
 	assert.Equal(t, origin, or)
 }
 
+func TestOriginSurvivesCopyAndMerge(t *testing.T) {
+	base := testConfigMap.DeepCopy()
+	baseOrigin := &Origin{Path: "base/configmap.yaml"}
+	require.NoError(t, base.SetOrigin(baseOrigin))
+
+	or, err := base.DeepCopy().GetOrigin()
+	require.NoError(t, err)
+	assert.Equal(t, baseOrigin, or)
+
+	// A resource without an origin takes the one it is merged from.
+	r := testConfigMap.DeepCopy()
+	require.NoError(t, r.CopyMergeMetaDataFieldsFrom(base))
+	or, err = r.GetOrigin()
+	require.NoError(t, err)
+	assert.Equal(t, baseOrigin, or)
+
+	// A resource with an origin keeps it.
+	overlayOrigin := &Origin{Path: "overlay/configmap.yaml"}
+	require.NoError(t, r.SetOrigin(overlayOrigin))
+	require.NoError(t, r.CopyMergeMetaDataFieldsKeepingIdFrom(base))
+	or, err = r.GetOrigin()
+	require.NoError(t, err)
+	assert.Equal(t, overlayOrigin, or)
+}
+
 func TestTransformations(t *testing.T) {
 	r, err := factory.FromBytes([]byte(`
 apiVersion: v1
@@ -1554,3 +2208,614 @@ spec:
   numReplicas: 1
 `, r.MustString())
 }
+
+func TestMergeStrict(t *testing.T) {
+	header := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`
+	base, err := factory.FromBytes([]byte(header + `
+data:
+  mode: prod
+  region: eu
+binaryData:
+  logo: aGVsbG8=
+`))
+	require.NoError(t, err)
+
+	overlay, err := factory.FromBytes([]byte(header + `
+data:
+  mode: prod
+  tier: web
+`))
+	require.NoError(t, err)
+	require.NoError(t, overlay.MergeStrict(base))
+	assert.Equal(t, `apiVersion: v1
+binaryData:
+  logo: aGVsbG8=
+data:
+  mode: prod
+  region: eu
+  tier: web
+kind: ConfigMap
+metadata:
+  name: cm
+`, overlay.MustYaml())
+
+	conflicting, err := factory.FromBytes([]byte(header + `
+data:
+  mode: dev
+`))
+	require.NoError(t, err)
+	err = conflicting.MergeStrict(base)
+	require.Error(t, err)
+	assert.Equal(t,
+		`cannot merge ConfigMap.v1.[noGrp]/cm.[noNs] into ConfigMap.v1.[noGrp]/cm.[noNs]: `+
+			`conflicting values for data key "mode": "prod" and "dev"`,
+		err.Error())
+	// The overlay is unchanged.
+	assert.Equal(t, `apiVersion: v1
+data:
+  mode: dev
+kind: ConfigMap
+metadata:
+  name: cm
+`, conflicting.MustYaml())
+
+	// Last-wins merging is unaffected.
+	conflicting.MergeDataMapFrom(base)
+	assert.Equal(t, map[string]string{"mode": "dev", "region": "eu"},
+		conflicting.GetDataMap())
+}
+
+func TestMergeStringDataMapFrom(t *testing.T) {
+	base, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+stringData:
+  username: admin
+  password: base
+  host: db
+`))
+	require.NoError(t, err)
+	overlay, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+data:
+  host: cmVwbGljYQ==
+stringData:
+  password: overlay
+  port: "5432"
+`))
+	require.NoError(t, err)
+	overlay.MergeStringDataMapFrom(base)
+	assert.Equal(t, `apiVersion: v1
+data:
+  host: cmVwbGljYQ==
+kind: Secret
+metadata:
+  name: creds
+stringData:
+  password: overlay
+  port: "5432"
+  username: admin
+`, overlay.MustYaml())
+}
+
+func TestBehaviorOfBareResource(t *testing.T) {
+	r := &Resource{}
+	assert.Equal(t, types.BehaviorUnspecified, r.Behavior())
+	assert.False(t, r.NeedHashSuffix())
+}
+
+func TestMergeSpecFrom(t *testing.T) {
+	base, err := factory.FromBytes([]byte(`
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: db
+  labels:
+    app: db
+spec:
+  engine: postgres
+  storage:
+    size: 10Gi
+    class: standard
+  replicas: 1
+`))
+	require.NoError(t, err)
+	overlay, err := factory.FromBytes([]byte(`
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: other
+  labels:
+    app: other
+spec:
+  storage:
+    size: 50Gi
+  replicas: 3
+  backup:
+    schedule: "@daily"
+`))
+	require.NoError(t, err)
+	require.NoError(t, base.MergeSpecFrom(overlay))
+	assert.Equal(t, `apiVersion: example.com/v1
+kind: Database
+metadata:
+  labels:
+    app: db
+  name: db
+spec:
+  backup:
+    schedule: '@daily'
+  engine: postgres
+  replicas: 3
+  storage:
+    class: standard
+    size: 50Gi
+`, base.MustYaml())
+}
+
+func TestMergeSpecFromStrategicMergeKeys(t *testing.T) {
+	base, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+      - name: proxy
+        image: proxy:v1
+`))
+	require.NoError(t, err)
+	overlay, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        image: proxy:v2
+`))
+	require.NoError(t, err)
+	require.NoError(t, base.MergeSpecFrom(overlay))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: proxy:v2
+        name: proxy
+      - image: app:v1
+        name: app
+`, base.MustYaml())
+}
+
+func TestRemoveBuildAnnotations(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    owner: team-a
+    config.kubernetes.io/local-config: "false"
+    internal.config.kubernetes.io/refBy: Deployment.v1.apps/app.[noNs]
+    internal.config.kubernetes.io/someFutureMarker: "true"
+`))
+	require.NoError(t, err)
+	r.RemoveBuildAnnotations()
+	// Annotations of other tools in the internal domain are kept.
+	assert.Equal(t, map[string]string{
+		"owner":                             "team-a",
+		"config.kubernetes.io/local-config": "false",
+		"internal.config.kubernetes.io/someFutureMarker": "true",
+	}, r.GetAnnotations())
+
+	r, err = factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    internal.config.kubernetes.io/needsHashSuffix: enabled
+    internal.config.kubernetes.io/refBy: Deployment.v1.apps/app.[noNs]
+`))
+	require.NoError(t, err)
+	r.RemoveBuildAnnotations()
+	yml, err := r.AsYAML()
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`, string(yml))
+}
+
+func TestLastTransformer(t *testing.T) {
+	r := testConfigMap.DeepCopy()
+	assert.Equal(t, "", r.GetLastTransformer())
+	r.SetLastTransformer("PrefixSuffixTransformer")
+	r.SetLastTransformer("LabelTransformer")
+	assert.Equal(t, "LabelTransformer", r.GetLastTransformer())
+	assert.Equal(t, "LabelTransformer",
+		r.GetAnnotations()[utils.BuildAnnotationLastTransformer])
+
+	r.RemoveBuildAnnotations()
+	assert.Equal(t, "", r.GetLastTransformer())
+	assert.Equal(t, testConfigMap.MustString(), r.MustString())
+}
+
+func TestAsYAMLPreservingComments(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`# The app's settings.
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: cm # keep short
+data:
+  # Log verbosity.
+  level: debug
+  mode: prod
+# Trailing note.
+`))
+	require.NoError(t, err)
+	yml, err := r.AsYAMLPreservingComments()
+	require.NoError(t, err)
+	assert.Equal(t, `# The app's settings.
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: cm # keep short
+data:
+  # Log verbosity.
+  level: debug
+  mode: prod
+# Trailing note.
+`, string(yml))
+
+	yml, err = r.AsYAML()
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+data:
+  level: debug
+  mode: prod
+kind: ConfigMap
+metadata:
+  name: cm
+`, string(yml))
+}
+
+func TestAsYAMLKeepsScalarTypes(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  annotations:
+    count: "10000000000"
+    enabled: "true"
+    exponent: "1e3"
+    zip: "0123"
+data:
+  "on": "yes"
+  zip: "007"
+spec:
+  big: 10000000000
+  huge: 123456789012345678
+  ratio: 1.5
+`))
+	require.NoError(t, err)
+	yml, err := r.AsYAML()
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+data:
+  "on": "yes"
+  zip: "007"
+kind: ConfigMap
+metadata:
+  annotations:
+    count: "10000000000"
+    enabled: "true"
+    exponent: "1e3"
+    zip: "0123"
+  name: cm
+spec:
+  big: 10000000000
+  huge: 123456789012345678
+  ratio: 1.5
+`, string(yml))
+}
+
+func TestAsYAMLUsesBlockScalars(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  script: "#!/bin/sh\nset -e\necho hello\n"
+`))
+	require.NoError(t, err)
+	yml, err := r.AsYAML()
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+data:
+  script: |
+    #!/bin/sh
+    set -e
+    echo hello
+kind: ConfigMap
+metadata:
+  name: cm
+`, string(yml))
+	script, err := r.GetString("data.script")
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\nset -e\necho hello\n", script)
+}
+
+func TestAsYAMLWithSeparator(t *testing.T) {
+	var stream []byte
+	for _, r := range []*Resource{testConfigMap, testDeployment} {
+		y, err := r.AsYAMLWithSeparator()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(y), "---\n"))
+		assert.True(t, strings.HasSuffix(string(y), "\n"))
+		assert.False(t, strings.HasSuffix(string(y), "\n\n"))
+		stream = append(stream, y...)
+	}
+	resources, err := factory.SliceFromBytes(stream)
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, testConfigMap.String(), resources[0].String())
+	assert.Equal(t, testDeployment.String(), resources[1].String())
+
+	y, err := testDeployment.AsYAML()
+	require.NoError(t, err)
+	assert.False(t, strings.HasPrefix(string(y), "---"))
+}
+
+func TestMapIsACopy(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: honey
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+`))
+	require.NoError(t, err)
+	before := r.MustString()
+	m, err := r.Map()
+	require.NoError(t, err)
+
+	m["kind"] = "StatefulSet"
+	m["metadata"].(map[string]interface{})["labels"].(map[string]interface{})["app"] = "pot"
+	containers := m["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})
+	containers[0].(map[string]interface{})["image"] = "app:v2"
+	containers = append(containers, map[string]interface{}{"name": "sidecar"})
+	m["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"] = containers
+
+	assert.Equal(t, before, r.MustString())
+}
+
+func TestMarshalJSONIsDeterministic(t *testing.T) {
+	r1, err := factory.FromBytes([]byte(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: app
+  labels: {z: "1", a: "2", m: "3"}
+spec:
+  template:
+    spec:
+      containers:
+      - name: b
+        image: b
+      - image: a
+        name: a
+`))
+	require.NoError(t, err)
+	r2, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+metadata:
+  labels: {m: "3", a: "2", z: "1"}
+  name: app
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+      - image: b
+        name: b
+      - name: a
+        image: a
+`))
+	require.NoError(t, err)
+	const expected = `{"apiVersion":"apps/v1","kind":"Deployment",` +
+		`"metadata":{"labels":{"a":"2","m":"3","z":"1"},"name":"app"},` +
+		`"spec":{"template":{"spec":{"containers":` +
+		`[{"image":"b","name":"b"},{"image":"a","name":"a"}]}}}}`
+	for i := 0; i < 10; i++ {
+		for _, r := range []*Resource{r1, r2} {
+			j, err := r.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(j))
+		}
+	}
+}
+
+func TestApplyStrategicMergePatch(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v1
+      - name: proxy
+        image: proxy:v1
+`))
+	require.NoError(t, err)
+	require.NoError(t, r.ApplyStrategicMergePatch([]byte(`
+metadata:
+  name: ignored
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        image: proxy:v2
+      - name: debug
+        image: debug
+`)))
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: proxy:v2
+        name: proxy
+      - image: debug
+        name: debug
+      - image: app:v1
+        name: app
+`, r.MustYaml())
+
+	crd, err := factory.FromBytes([]byte(`
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: w
+spec:
+  size: 1
+  parts:
+  - name: a
+  - name: b
+`))
+	require.NoError(t, err)
+	require.NoError(t, crd.ApplyStrategicMergePatch(
+		[]byte(`{"spec": {"parts": [{"name": "c"}]}}`)))
+	assert.Equal(t, `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: w
+spec:
+  parts:
+  - name: c
+  size: 1
+`, crd.MustYaml())
+
+	require.EqualError(t, crd.ApplyStrategicMergePatch([]byte(`- a`)),
+		"invalid strategic merge patch: not a map")
+}
+
+func TestApplyJSON6902Patch(t *testing.T) {
+	const original = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - image: app:v1
+        name: app
+`
+	tests := map[string]struct {
+		ops      string
+		expected string
+		err      string
+	}{
+		"add, replace and remove": {
+			ops: `[
+{"op": "replace", "path": "/spec/replicas", "value": 3},
+{"op": "add", "path": "/metadata/labels", "value": {"app": "web"}},
+{"op": "remove", "path": "/spec/template/spec/containers/0/image"}
+]`,
+			expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: web
+  name: app
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: app
+`,
+		},
+		"yaml": {
+			ops: `
+- op: test
+  path: /spec/replicas
+  value: 1
+- op: replace
+  path: /spec/replicas
+  value: 2
+`,
+			expected: strings.Replace(original, "replicas: 1", "replicas: 2", 1),
+		},
+		"failed test": {
+			ops: `[
+{"op": "replace", "path": "/spec/replicas", "value": 3},
+{"op": "test", "path": "/spec/replicas", "value": 1}
+]`,
+			err: "testing value /spec/replicas failed",
+		},
+		"remove missing path": {
+			ops: `[
+{"op": "replace", "path": "/spec/replicas", "value": 3},
+{"op": "remove", "path": "/spec/paused"}
+]`,
+			err: "Unable to remove nonexistent key: paused",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := factory.FromBytes([]byte(original))
+			require.NoError(t, err)
+			err = r.ApplyJSON6902Patch([]byte(tc.ops))
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				assert.Equal(t, original, r.MustYaml())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, r.MustYaml())
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"fmt"
+
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// HasStatus returns true if the resource has a status field,
+// even an empty one.
+func (r *Resource) HasStatus() bool {
+	return r.Field("status") != nil
+}
+
+// RemoveStatus removes the status field of the resource, if any.
+func (r *Resource) RemoveStatus() {
+	if err := r.PipeE(kyaml.Clear("status")); err != nil {
+		panic(err)
+	}
+}
+
+// liveClusterFields are the metadata fields that the API server sets
+// on the objects it stores, e.g. as seen in kubectl get -o yaml output.
+var liveClusterFields = []string{ //nolint:gochecknoglobals
+	"managedFields",
+	"creationTimestamp",
+	"resourceVersion",
+	"uid",
+	"generation",
+	"selfLink",
+}
+
+// RemoveLiveClusterFields removes the metadata fields that the API
+// server sets, such as managedFields and resourceVersion, so that a
+// resource dumped from a cluster can be used as a kustomize input.
+func (r *Resource) RemoveLiveClusterFields() {
+	meta, err := r.Pipe(kyaml.Lookup(kyaml.MetadataField))
+	if err != nil {
+		panic(err)
+	}
+	if meta == nil {
+		return
+	}
+	for _, f := range liveClusterFields {
+		if err := meta.PipeE(kyaml.Clear(f)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Condition is an entry of the status.conditions list that
+// most Kubernetes kinds use to report their state.
+type Condition struct {
+	Type               string `json:"type" yaml:"type"`
+	Status             string `json:"status" yaml:"status"`
+	Reason             string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message            string `json:"message,omitempty" yaml:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty" yaml:"lastTransitionTime,omitempty"`
+}
+
+// GetConditions returns the status conditions of the resource,
+// or an empty slice if it has none. It returns an error if
+// status.conditions is not a list of conditions.
+func (r *Resource) GetConditions() ([]Condition, error) {
+	node, err := r.Pipe(kyaml.Lookup("status", "conditions"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot look up status.conditions: %w", err)
+	}
+	conditions := []Condition{}
+	if node == nil {
+		return conditions, nil
+	}
+	if err = node.YNode().Decode(&conditions); err != nil {
+		return nil, fmt.Errorf(
+			"status.conditions of %s is malformed: %w", r.CurId(), err)
+	}
+	return conditions, nil
+}
+
+// GetConditionByType returns the status condition of the given
+// type, and whether there is one. It returns false if the
+// conditions cannot be parsed.
+func (r *Resource) GetConditionByType(t string) (*Condition, bool) {
+	conditions, err := r.GetConditions()
+	if err != nil {
+		return nil, false
+	}
+	for i := range conditions {
+		if conditions[i].Type == t {
+			return &conditions[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resource"
+)
+
+func TestRemoveStatus(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input     string
+		hasStatus bool
+	}{
+		"with status": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+status:
+  replicas: 2
+`,
+			hasStatus: true,
+		},
+		"empty status": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+status: {}
+`,
+			hasStatus: true,
+		},
+		"no status": {
+			input: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r, err := factory.FromBytes([]byte(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.hasStatus, r.HasStatus())
+			r.RemoveStatus()
+			assert.False(t, r.HasStatus())
+			assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+`, r.MustString())
+		})
+	}
+}
+
+func TestRemoveLiveClusterFields(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    deployment.kubernetes.io/revision: "1"
+  creationTimestamp: "2023-05-01T10:00:00Z"
+  generation: 1
+  labels:
+    app: web
+  managedFields:
+  - apiVersion: apps/v1
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:replicas: {}
+    manager: kubectl-client-side-apply
+    operation: Update
+    time: "2023-05-01T10:00:00Z"
+  name: web
+  namespace: default
+  resourceVersion: "123456"
+  selfLink: /apis/apps/v1/namespaces/default/deployments/web
+  uid: 0c5b6f6e-1d6a-4a53-9d5e-5e6f6b1b2c3d
+spec:
+  replicas: 1
+  template:
+    metadata:
+      creationTimestamp: null
+      labels:
+        app: web
+`))
+	require.NoError(t, err)
+	r.RemoveLiveClusterFields()
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    deployment.kubernetes.io/revision: "1"
+  labels:
+    app: web
+  name: web
+  namespace: default
+spec:
+  replicas: 1
+  template:
+    metadata:
+      creationTimestamp: null
+      labels:
+        app: web
+`, r.MustString())
+
+	r.RemoveLiveClusterFields()
+	rc := testConfigMap.DeepCopy()
+	rc.RemoveLiveClusterFields()
+	assert.Equal(t, testConfigMap.MustString(), rc.MustString())
+}
+
+func TestGetConditions(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+status:
+  observedGeneration: 3
+  replicas: 2
+  conditions:
+  - type: Available
+    status: "True"
+    reason: MinimumReplicasAvailable
+    message: Deployment has minimum availability.
+    lastTransitionTime: "2023-05-01T10:00:00Z"
+    lastUpdateTime: "2023-05-01T10:00:00Z"
+  - type: Progressing
+    status: "False"
+    reason: ProgressDeadlineExceeded
+`))
+	require.NoError(t, err)
+	conditions, err := r.GetConditions()
+	require.NoError(t, err)
+	assert.Equal(t, []Condition{
+		{
+			Type:               "Available",
+			Status:             "True",
+			Reason:             "MinimumReplicasAvailable",
+			Message:            "Deployment has minimum availability.",
+			LastTransitionTime: "2023-05-01T10:00:00Z",
+		},
+		{
+			Type:   "Progressing",
+			Status: "False",
+			Reason: "ProgressDeadlineExceeded",
+		},
+	}, conditions)
+
+	c, ok := r.GetConditionByType("Progressing")
+	require.True(t, ok)
+	assert.Equal(t, "ProgressDeadlineExceeded", c.Reason)
+	_, ok = r.GetConditionByType("ReplicaFailure")
+	assert.False(t, ok)
+}
+
+func TestGetConditionsWithoutStatus(t *testing.T) {
+	conditions, err := testConfigMap.GetConditions()
+	require.NoError(t, err)
+	assert.NotNil(t, conditions)
+	assert.Empty(t, conditions)
+	_, ok := testConfigMap.GetConditionByType("Ready")
+	assert.False(t, ok)
+}
+
+func TestGetConditionsMalformed(t *testing.T) {
+	r, err := factory.FromBytes([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+status:
+  conditions:
+    type: Available
+`))
+	require.NoError(t, err)
+	_, err = r.GetConditions()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(),
+		"status.conditions of Deployment.v1.apps/app.[noNs] is malformed")
+	_, ok := r.GetConditionByType("Available")
+	assert.False(t, ok)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/internal/utils"
+)
+
+// dnsLabel matches an RFC 1123 label, the syntax of a namespace.
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+const maxDNSLabelLength = 63
+
+// ValidationError lists every problem that Validate found with
+// a resource.
+type ValidationError struct {
+	// Resource is the kind and name of the resource, as far as
+	// it has them.
+	Resource string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid resource %s:\n%s",
+		e.Resource, strings.Join(e.Problems, "\n"))
+}
+
+// Validate returns a *ValidationError if the resource lacks an
+// apiVersion, a kind or, unless it's generated, a name, or if its
+// namespace isn't a valid DNS label.
+func (r *Resource) Validate() error {
+	var problems []string
+	if r.GetApiVersion() == "" {
+		problems = append(problems, "apiVersion is missing")
+	}
+	if r.GetKind() == "" {
+		problems = append(problems, "kind is missing")
+	}
+	_, generated := r.GetAnnotation(utils.BuildAnnotationsGenBehavior)
+	if !generated && r.GetName() == "" {
+		problems = append(problems, "metadata.name is missing")
+	}
+	if ns := r.GetNamespace(); ns != "" &&
+		(len(ns) > maxDNSLabelLength || !dnsLabel.MatchString(ns)) {
+		problems = append(problems, fmt.Sprintf(
+			"metadata.namespace %q is not a valid DNS label", ns))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{
+		Resource: strings.TrimSpace(r.GetKind() + " " + r.GetName()),
+		Problems: problems,
+	}
+}
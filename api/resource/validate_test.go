@@ -0,0 +1,80 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		in       map[string]interface{}
+		problems []string
+	}{
+		"valid": {
+			in: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      "cm",
+					"namespace": "team-a",
+				},
+			},
+		},
+		"everything missing": {
+			in: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"namespace": "Team_A",
+				},
+			},
+			problems: []string{
+				"apiVersion is missing",
+				"kind is missing",
+				"metadata.name is missing",
+				`metadata.namespace "Team_A" is not a valid DNS label`,
+			},
+		},
+		"namespace too long": {
+			in: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      "cm",
+					"namespace": "n234567890123456789012345678901234567890123456789012345678901234",
+				},
+			},
+			problems: []string{
+				`metadata.namespace "n234567890123456789012345678901234567890123456789012345678901234" is not a valid DNS label`,
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := factory.FromMap(tc.in).Validate()
+			if tc.problems == nil {
+				require.NoError(t, err)
+				return
+			}
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tc.problems, verr.Problems)
+		})
+	}
+}
+
+func TestValidateGeneratedWithoutName(t *testing.T) {
+	r := factory.FromMap(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+	})
+	require.EqualError(t, r.Validate(),
+		"invalid resource ConfigMap:\nmetadata.name is missing")
+	r.SetBehavior(types.BehaviorCreate)
+	require.NoError(t, r.Validate())
+}
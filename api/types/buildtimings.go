@@ -0,0 +1,70 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "time"
+
+// BuildPhase is a phase of a kustomize build.
+type BuildPhase string
+
+const (
+	// BuildPhaseLoad reads kustomization and resource files.
+	BuildPhaseLoad BuildPhase = "load"
+	// BuildPhaseGenerate runs generators.
+	BuildPhaseGenerate BuildPhase = "generate"
+	// BuildPhaseTransform runs transformers.
+	BuildPhaseTransform BuildPhase = "transform"
+	// BuildPhaseEmit sorts and cleans up the output.
+	BuildPhaseEmit BuildPhase = "emit"
+)
+
+// BuildPhases lists the build phases in the order they start.
+func BuildPhases() []BuildPhase {
+	return []BuildPhase{
+		BuildPhaseLoad, BuildPhaseGenerate, BuildPhaseTransform, BuildPhaseEmit}
+}
+
+// TransformerTiming is the time taken by one run of a transformer.
+type TransformerTiming struct {
+	Transformer string        `json:"transformer" yaml:"transformer"`
+	Duration    time.Duration `json:"duration" yaml:"duration"`
+}
+
+// BuildTimings reports where the time of a build went. Phases
+// hold the total time spent in each phase over all the
+// kustomizations of the build; Transformers hold the time of
+// each transformer run, in the order they ran.
+type BuildTimings struct {
+	Phases       map[BuildPhase]time.Duration `json:"phases" yaml:"phases"`
+	Transformers []TransformerTiming          `json:"transformers,omitempty" yaml:"transformers,omitempty"`
+}
+
+// NewBuildTimings returns an empty BuildTimings with every phase.
+func NewBuildTimings() *BuildTimings {
+	t := &BuildTimings{Phases: make(map[BuildPhase]time.Duration)}
+	for _, p := range BuildPhases() {
+		t.Phases[p] = 0
+	}
+	return t
+}
+
+// Time runs f, adding the time it takes to the given phase.
+func (t *BuildTimings) Time(phase BuildPhase, f func() error) error {
+	start := time.Now()
+	err := f()
+	t.Phases[phase] += time.Since(start)
+	return err
+}
+
+// TimeTransformer runs f, recording the time it takes as a run of
+// the named transformer.
+func (t *BuildTimings) TimeTransformer(name string, f func() error) error {
+	start := time.Now()
+	err := f()
+	t.Transformers = append(t.Transformers, TransformerTiming{
+		Transformer: name,
+		Duration:    time.Since(start),
+	})
+	return err
+}
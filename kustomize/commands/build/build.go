@@ -31,6 +31,9 @@ var theFlags struct {
 	loadRestrictor string
 	reorderOutput  string
 	fnOptions      types.FnPluginLoadingOptions
+
+	preserveComments bool
+	hashManifestPath string
 }
 
 type Help struct {
@@ -83,12 +86,24 @@ func NewCmdBuild(
 			if err != nil {
 				return err
 			}
+			for _, w := range k.Warnings() {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", w)
+			}
+			if theFlags.hashManifestPath != "" {
+				if err = writeHashManifest(fSys, m); err != nil {
+					return err
+				}
+			}
 			if theFlags.outputPath != "" && fSys.IsDir(theFlags.outputPath) {
 				// Ignore writer; write to o.outputPath directly.
 				return MakeWriter(fSys).WriteIndividualFiles(
 					theFlags.outputPath, m)
 			}
-			yml, err := m.AsYaml()
+			asYaml := m.AsYaml
+			if theFlags.preserveComments {
+				asYaml = m.AsYamlPreservingComments
+			}
+			yml, err := asYaml()
 			if err != nil {
 				return err
 			}
@@ -106,6 +121,8 @@ func NewCmdBuild(
 	AddFlagEnablePlugins(cmd.Flags())
 	AddFlagReorderOutput(cmd.Flags())
 	AddFlagEnableManagedbyLabel(cmd.Flags())
+	AddFlagPreserveComments(cmd.Flags())
+	AddFlagHashManifest(cmd.Flags())
 	msg := "Error marking flag '%s' as deprecated: %v"
 	err := cmd.Flags().MarkDeprecated(flagReorderOutputName,
 		"use the new 'sortOptions' field in kustomization.yaml instead.")
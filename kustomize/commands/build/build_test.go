@@ -197,6 +197,35 @@ type: Opaque
 	}
 }
 
+func TestBuildWithHashManifest(t *testing.T) {
+	fSys := filesys.MakeFsInMemory()
+	loadFileSystem(fSys)
+	buffy := new(bytes.Buffer)
+	cmd := NewCmdBuild(fSys, MakeHelp("foo", "bar"), buffy)
+	cmd.Flags().Set("hash-manifest", "hashes.yaml")
+	defer cmd.Flags().Set("hash-manifest", "")
+	if err := cmd.RunE(cmd, []string{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := fSys.ReadFile("hashes.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"ns1",
+		"foo-literalConfigMap-bar-g5f6t456f5",
+		"foo-secret-bar-82c2g5f8f6",
+		"foo-dply1-bar",
+	} {
+		if !strings.Contains(string(data), "name: "+name+"\n") {
+			t.Fatalf("expected %s in hash manifest:\n%s", name, data)
+		}
+	}
+	if n := strings.Count(string(data), "hash: "); n != 4 {
+		t.Fatalf("expected 4 hashes, got %d:\n%s", n, data)
+	}
+}
+
 func TestHelp(t *testing.T) {
 	fSys := filesys.MakeFsInMemory()
 	buffy := new(bytes.Buffer)
@@ -0,0 +1,32 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+func AddFlagHashManifest(set *pflag.FlagSet) {
+	set.StringVar(
+		&theFlags.hashManifestPath,
+		"hash-manifest",
+		"",
+		"If specified, also write to this path a manifest listing "+
+			"each output resource and the sha256 of its content.")
+}
+
+func writeHashManifest(fSys filesys.FileSystem, m resmap.ResMap) error {
+	entries, err := resmap.MakeHashManifest(m)
+	if err != nil {
+		return err
+	}
+	yml, err := yaml.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return fSys.WriteFile(theFlags.hashManifestPath, yml)
+}
@@ -0,0 +1,17 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"github.com/spf13/pflag"
+)
+
+func AddFlagPreserveComments(set *pflag.FlagSet) {
+	set.BoolVar(
+		&theFlags.preserveComments,
+		"preserve-comments",
+		false,
+		"Keep comments from the input on fields that no transformer "+
+			"rewrote. Fields keep their input order.")
+}
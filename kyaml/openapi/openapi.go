@@ -576,6 +576,13 @@ func SetSchema(openAPIField map[string]string, schema []byte, reset bool) error
 	if schemaIsSet && !reset {
 		return nil
 	}
+	if reset && (customSchema != nil || schema != nil) {
+		// Forget the resource scopes of the previous custom schema,
+		// e.g. cluster-scoped CRDs, so they don't outlive its build.
+		customSchema = nil
+		globalSchema.namespaceabilityByResourceType = nil
+		globalSchema.schemaInit = false
+	}
 
 	version, versionProvided := openAPIField["version"]
 
@@ -328,6 +328,33 @@ func TestIsNamespaceScoped_custom(t *testing.T) {
 	assert.True(t, isNamespaceable)
 }
 
+func TestSetSchemaResetForgetsCustomScopes(t *testing.T) {
+	ResetOpenAPI()
+	defer ResetOpenAPI()
+	clusterCustom := yaml.TypeMeta{APIVersion: "custom.io/v1", Kind: "ClusterCustom"}
+	err := SetSchema(nil, []byte(`
+{
+  "definitions": {},
+  "paths": {
+    "/apis/custom.io/v1/clustercustoms": {
+      "get": {
+        "x-kubernetes-group-version-kind": {
+          "group": "custom.io",
+          "kind": "ClusterCustom",
+          "version": "v1"
+        }
+      }
+    }
+  }
+}
+`), true)
+	require.NoError(t, err)
+	assert.True(t, IsCertainlyClusterScoped(clusterCustom))
+
+	require.NoError(t, SetSchema(nil, nil, true))
+	assert.False(t, IsCertainlyClusterScoped(clusterCustom))
+}
+
 func TestCanSetAndResetSchemaConcurrently(t *testing.T) {
 	t.Run("SetSchema doesn't cause a data race when called concurrently", func(t *testing.T) {
 		set := func(wg *sync.WaitGroup) {
@@ -253,3 +253,12 @@ func (x Gvk) AsTypeMeta() yaml.TypeMeta {
 func (x Gvk) IsClusterScoped() bool {
 	return x.isClusterScoped
 }
+
+// IsNamespaceable returns true if objects of the Gvk can have a
+// namespace, i.e. unless the Gvk is known to be cluster scoped per
+// the openapi data in use. Unknown kinds, e.g. of most CRDs, are
+// namespaceable; a build declares cluster-scoped CRDs in the schema
+// of the kustomization's openapi field.
+func (x Gvk) IsNamespaceable() bool {
+	return !x.isClusterScoped
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
 var equalsTests = []struct {
@@ -326,6 +327,54 @@ func TestIsClusterScoped(t *testing.T) {
 		test := testCases[i]
 		t.Run(test.name, func(t *testing.T) {
 			assert.Equal(t, test.isClusterScoped, test.gvk.IsClusterScoped())
+			assert.Equal(t, !test.isClusterScoped, test.gvk.IsNamespaceable())
 		})
 	}
 }
+
+func TestIsNamespaceable(t *testing.T) {
+	for _, gvk := range []Gvk{
+		NewGvk("", "v1", "Namespace"),
+		NewGvk("", "v1", "Node"),
+		NewGvk("", "v1", "PersistentVolume"),
+		NewGvk("rbac.authorization.k8s.io", "v1", "ClusterRole"),
+		NewGvk("rbac.authorization.k8s.io", "v1", "ClusterRoleBinding"),
+		NewGvk("apiextensions.k8s.io", "v1", "CustomResourceDefinition"),
+		NewGvk("storage.k8s.io", "v1", "StorageClass"),
+	} {
+		assert.False(t, gvk.IsNamespaceable(), gvk.String())
+	}
+	for _, gvk := range []Gvk{
+		NewGvk("", "v1", "ConfigMap"),
+		NewGvk("apps", "v1", "Deployment"),
+		NewGvk("example.com", "v1", "Widget"),
+	} {
+		assert.True(t, gvk.IsNamespaceable(), gvk.String())
+	}
+}
+
+func TestIsNamespaceableCustomSchema(t *testing.T) {
+	openapi.ResetOpenAPI()
+	defer openapi.ResetOpenAPI()
+	assert.True(t, NewGvk("cluster.example.com", "v1", "Fleet").IsNamespaceable())
+
+	assert.NoError(t, openapi.AddSchema([]byte(`
+{
+  "definitions": {},
+  "paths": {
+    "/apis/cluster.example.com/v1/fleets/{name}": {
+      "get": {
+        "x-kubernetes-group-version-kind": {
+          "group": "cluster.example.com",
+          "kind": "Fleet",
+          "version": "v1"
+        }
+      }
+    }
+  }
+}
+`)))
+	assert.False(t, NewGvk("cluster.example.com", "v1", "Fleet").IsNamespaceable())
+	assert.True(t, NewGvk("cluster.example.com", "v1", "Ship").IsNamespaceable())
+	assert.True(t, NewGvk("cluster.example.com", "v2", "Fleet").IsNamespaceable())
+}
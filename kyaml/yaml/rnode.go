@@ -1208,6 +1208,9 @@ func FromMap(m map[string]interface{}) (*RNode, error) {
 	return Parse(string(c))
 }
 
+// Map returns the node decoded into a map. The map and everything
+// in it are newly allocated, so the caller may change them without
+// changing the node.
 func (rn *RNode) Map() (map[string]interface{}, error) {
 	if rn == nil || rn.value == nil {
 		return make(map[string]interface{}), nil
@@ -0,0 +1,69 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/prefix"
+	"sigs.k8s.io/kustomize/api/filters/suffix"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Give the names of child objects embedded in the selected
+// resources, e.g. the named sub-objects of a custom resource,
+// the name prefixes and suffixes their parent resource got.
+// It must run after the transformers adding the prefixes and
+// suffixes, e.g. in the transformers field of the kustomization.
+type plugin struct {
+	Target     *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+	FieldSpecs types.FsSlice   `json:"fieldSpecs,omitempty" yaml:"fieldSpecs,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Target = nil
+	p.FieldSpecs = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.Target == nil {
+		return fmt.Errorf("must specify a target")
+	}
+	if len(p.FieldSpecs) == 0 {
+		return fmt.Errorf("must specify the fieldSpecs of the child names")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) error {
+	resources, err := m.Select(*p.Target)
+	if err != nil {
+		return err
+	}
+	for _, r := range resources {
+		for _, fs := range p.FieldSpecs {
+			// Prefixes and suffixes are recorded innermost first,
+			// the order they must be applied in.
+			for _, pfx := range r.GetNamePrefixes() {
+				err = r.ApplyFilter(prefix.Filter{Prefix: pfx, FieldSpec: fs})
+				if err != nil {
+					return err
+				}
+			}
+			for _, sfx := range r.GetNameSuffixes() {
+				err = r.ApplyFilter(suffix.Filter{Suffix: sfx, FieldSpec: fs})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
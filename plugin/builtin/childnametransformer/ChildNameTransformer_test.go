@@ -0,0 +1,75 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestChildNameTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ChildNameTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: ChildNameTransformer
+metadata:
+  name: notImportantHere
+target:
+  kind: Gateway
+fieldSpecs:
+- path: spec/listeners/name
+- path: spec/tls/certificateRef
+`, `
+apiVersion: example.com/v1
+kind: Gateway
+metadata:
+  name: prod-edge-v2
+  annotations:
+    internal.config.kubernetes.io/prefixes: edge-,prod-
+    internal.config.kubernetes.io/suffixes: -v2
+spec:
+  listeners:
+  - name: http
+    port: 80
+  - name: https
+    port: 443
+  tls:
+    certificateRef: cert
+---
+apiVersion: example.com/v1
+kind: Route
+metadata:
+  name: prod-route
+  annotations:
+    internal.config.kubernetes.io/prefixes: prod-
+spec:
+  listeners:
+  - name: http
+`, `
+apiVersion: example.com/v1
+kind: Gateway
+metadata:
+  name: prod-edge-v2
+spec:
+  listeners:
+  - name: prod-edge-http-v2
+    port: 80
+  - name: prod-edge-https-v2
+    port: 443
+  tls:
+    certificateRef: prod-edge-cert-v2
+---
+apiVersion: example.com/v1
+kind: Route
+metadata:
+  name: prod-route
+spec:
+  listeners:
+  - name: http
+`)
+}
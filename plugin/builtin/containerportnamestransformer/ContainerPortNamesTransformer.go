@@ -0,0 +1,45 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/portnames"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Name the unnamed container ports of workloads after their
+// protocol and number, and optionally renumber ports by name.
+type plugin struct {
+	Numbers map[string]int  `json:"numbers,omitempty" yaml:"numbers,omitempty"`
+	Target  *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Numbers = nil
+	p.Target = nil
+	return yaml.Unmarshal(c, p)
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(portnames.Filter{Numbers: p.Numbers})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
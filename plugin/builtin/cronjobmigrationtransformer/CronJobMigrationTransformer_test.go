@@ -0,0 +1,93 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestCronJobMigrationTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("CronJobMigrationTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: CronJobMigrationTransformer
+metadata:
+  name: notImportantHere
+`, `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  successfulJobsHistoryLimit: 2
+  jobTemplate:
+    spec:
+      backoffLimit: 4
+      template:
+        spec:
+          restartPolicy: OnFailure
+          containers:
+          - name: backup
+            image: backup:1.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`, `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      backoffLimit: 4
+      template:
+        spec:
+          containers:
+          - image: backup:1.0
+            name: backup
+          restartPolicy: OnFailure
+  schedule: 0 3 * * *
+  successfulJobsHistoryLimit: 2
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+}
+
+func TestCronJobMigrationTransformerInvalid(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("CronJobMigrationTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: CronJobMigrationTransformer
+metadata:
+  name: notImportantHere
+`, `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if err.Error() != `CronJob "backup" has no valid spec.jobTemplate` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/keyrefs"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Move keys from a ConfigMap to a Secret, or from a Secret to a
+// ConfigMap, and change the env vars of the workloads in the same
+// namespace that take those keys to take them from the destination.
+type plugin struct {
+	From      keyrefs.Source `json:"from,omitempty" yaml:"from,omitempty"`
+	To        keyrefs.Source `json:"to,omitempty" yaml:"to,omitempty"`
+	Namespace string         `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Keys      []string       `json:"keys,omitempty" yaml:"keys,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.From = keyrefs.Source{}
+	p.To = keyrefs.Source{}
+	p.Namespace = ""
+	p.Keys = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	for _, s := range []keyrefs.Source{p.From, p.To} {
+		if s.Kind != "ConfigMap" && s.Kind != "Secret" {
+			return fmt.Errorf("kind must be ConfigMap or Secret, not %q", s.Kind)
+		}
+		if s.Name == "" {
+			return fmt.Errorf("must specify a name for the %s", s.Kind)
+		}
+	}
+	if p.From.Kind == p.To.Kind {
+		return fmt.Errorf("can only move keys between a ConfigMap and a Secret")
+	}
+	if len(p.Keys) == 0 {
+		return fmt.Errorf("must specify keys to move")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) error {
+	from, err := p.find(m, p.From)
+	if err != nil {
+		return err
+	}
+	to, err := p.find(m, p.To)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string, len(p.Keys))
+	for _, k := range p.Keys {
+		if hasKey(to, k) {
+			return fmt.Errorf("key %q already exists in %s", k, to.CurId())
+		}
+		if values[k], err = takeValue(from, k); err != nil {
+			return err
+		}
+	}
+	if to.GetKind() == "Secret" {
+		err = to.LoadMapIntoSecretData(values)
+	} else {
+		err = to.LoadMapIntoConfigMapData(values)
+	}
+	if err != nil {
+		return err
+	}
+	// References use either the current names or, if they haven't
+	// been fixed yet, the original names.
+	filters := []keyrefs.Filter{{
+		From: keyrefs.Source{Kind: p.From.Kind, Name: from.GetName()},
+		To:   keyrefs.Source{Kind: p.To.Kind, Name: to.GetName()},
+		Keys: p.Keys,
+	}}
+	if org := from.OrgId().Name; org != from.GetName() {
+		filters = append(filters, keyrefs.Filter{
+			From: keyrefs.Source{Kind: p.From.Kind, Name: org},
+			To:   keyrefs.Source{Kind: p.To.Kind, Name: to.OrgId().Name},
+			Keys: p.Keys,
+		})
+	}
+	for _, r := range m.Resources() {
+		if !r.IsWorkload() || r.GetNamespace() != from.GetNamespace() {
+			continue
+		}
+		for _, f := range filters {
+			if err = r.ApplyFilter(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// find returns the one resource with the source's kind and name.
+func (p *plugin) find(
+	m resmap.ResMap, s keyrefs.Source) (*resource.Resource, error) {
+	matches := m.GetMatchingResourcesByAnyId(func(id resid.ResId) bool {
+		return id.Kind == s.Kind && id.Name == s.Name &&
+			(p.Namespace == "" || id.EffectiveNamespace() == p.Namespace)
+	})
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("cannot find %s %s", s.Kind, s.Name)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("found multiple resources for %s %s", s.Kind, s.Name)
+	}
+}
+
+// dataFields returns the fields of a ConfigMap or Secret that
+// hold its keys.
+func dataFields(r *resource.Resource) []string {
+	if r.GetKind() == "Secret" {
+		return []string{kyaml.DataField, "stringData"}
+	}
+	return []string{kyaml.DataField, kyaml.BinaryDataField}
+}
+
+func hasKey(r *resource.Resource, key string) bool {
+	for _, field := range dataFields(r) {
+		if value, _ := r.Pipe(kyaml.Lookup(field, key)); value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// takeValue removes the key from the ConfigMap or Secret and
+// returns its decoded value.
+func takeValue(r *resource.Resource, key string) (string, error) {
+	for _, field := range dataFields(r) {
+		data, err := r.Pipe(kyaml.Lookup(field))
+		if err != nil {
+			return "", err
+		}
+		if data == nil {
+			continue
+		}
+		value := data.Field(key)
+		if value.IsNilOrEmpty() {
+			continue
+		}
+		s := kyaml.GetValue(value.Value)
+		// Secret data and ConfigMap binaryData are base64 encoded.
+		if field == kyaml.BinaryDataField ||
+			(field == kyaml.DataField && r.GetKind() == "Secret") {
+			decoded, err := base64.StdEncoding.DecodeString(
+				strings.ReplaceAll(s, "\n", ""))
+			if err != nil {
+				return "", fmt.Errorf(
+					"cannot decode %s key %q of %s: %w", field, key, r.CurId(), err)
+			}
+			s = string(decoded)
+		}
+		if _, err = data.Pipe(kyaml.FieldClearer{Name: key}); err != nil {
+			return "", err
+		}
+		if len(data.Content()) == 0 {
+			if err = r.PipeE(kyaml.Clear(field)); err != nil {
+				return "", err
+			}
+		}
+		return s, nil
+	}
+	return "", fmt.Errorf("key %q not found in %s", key, r.CurId())
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+const dataMoveResources = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+data:
+  host: db.example.com
+  password: hunter2
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+type: Opaque
+data:
+  username: YWRtaW4=
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        env:
+        - name: HOST
+          valueFrom:
+            configMapKeyRef:
+              name: app-config
+              key: host
+        - name: PASSWORD
+          valueFrom:
+            configMapKeyRef:
+              name: app-config
+              key: password
+        - name: USERNAME
+          valueFrom:
+            secretKeyRef:
+              name: app-secret
+              key: username
+`
+
+func TestDataMoveTransformerToSecret(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("DataMoveTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: DataMoveTransformer
+metadata:
+  name: notImportantHere
+from:
+  kind: ConfigMap
+  name: app-config
+to:
+  kind: Secret
+  name: app-secret
+keys:
+- password
+`, dataMoveResources, `
+apiVersion: v1
+data:
+  host: db.example.com
+kind: ConfigMap
+metadata:
+  name: app-config
+---
+apiVersion: v1
+data:
+  password: aHVudGVyMg==
+  username: YWRtaW4=
+kind: Secret
+metadata:
+  name: app-secret
+type: Opaque
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - env:
+        - name: HOST
+          valueFrom:
+            configMapKeyRef:
+              key: host
+              name: app-config
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              key: password
+              name: app-secret
+        - name: USERNAME
+          valueFrom:
+            secretKeyRef:
+              key: username
+              name: app-secret
+        name: app
+`)
+}
+
+func TestDataMoveTransformerToConfigMap(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("DataMoveTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: DataMoveTransformer
+metadata:
+  name: notImportantHere
+from:
+  kind: Secret
+  name: app-secret
+to:
+  kind: ConfigMap
+  name: app-config
+keys:
+- username
+`, dataMoveResources, `
+apiVersion: v1
+data:
+  host: db.example.com
+  password: hunter2
+  username: admin
+kind: ConfigMap
+metadata:
+  name: app-config
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secret
+type: Opaque
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - env:
+        - name: HOST
+          valueFrom:
+            configMapKeyRef:
+              key: host
+              name: app-config
+        - name: PASSWORD
+          valueFrom:
+            configMapKeyRef:
+              key: password
+              name: app-config
+        - name: USERNAME
+          valueFrom:
+            configMapKeyRef:
+              key: username
+              name: app-config
+        name: app
+`)
+}
+
+func TestDataMoveTransformerMissingKey(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("DataMoveTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: DataMoveTransformer
+metadata:
+  name: notImportantHere
+from:
+  kind: ConfigMap
+  name: app-config
+to:
+  kind: Secret
+  name: app-secret
+keys:
+- token
+`, dataMoveResources)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		`key "token" not found in ConfigMap.v1.[noGrp]/app-config.[noNs]`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"sort"
+
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	"sigs.k8s.io/yaml"
+)
+
+const defaultPolicyName = "default-deny"
+
+// Add a NetworkPolicy denying all ingress and egress traffic to
+// each namespace in the build that has no NetworkPolicy selecting
+// all of its pods already. Egress DNS traffic may be allowed.
+type plugin struct {
+	h        *resmap.PluginHelpers
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	AllowDNS bool   `json:"allowDNS,omitempty" yaml:"allowDNS,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	h *resmap.PluginHelpers, c []byte) (err error) {
+	p.h = h
+	p.Name = ""
+	p.AllowDNS = false
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.Name == "" {
+		p.Name = defaultPolicyName
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) error {
+	byNamespace := m.GroupedByCurrentNamespace()
+	for _, r := range m.ClusterScoped() {
+		if r.GetKind() == "Namespace" {
+			if _, ok := byNamespace[r.GetName()]; !ok {
+				byNamespace[r.GetName()] = nil
+			}
+		}
+	}
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns, resources := range byNamespace {
+		if !hasNamespaceWidePolicy(resources) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		if err := m.Append(p.policy(ns)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasNamespaceWidePolicy returns true if one of the resources is a
+// NetworkPolicy with an empty pod selector.
+func hasNamespaceWidePolicy(resources []*resource.Resource) bool {
+	for _, r := range resources {
+		if r.GetKind() != "NetworkPolicy" {
+			continue
+		}
+		selector, err := r.GetFieldValue("spec.podSelector")
+		if err != nil {
+			continue
+		}
+		if s, ok := selector.(map[string]interface{}); ok && len(s) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *plugin) policy(ns string) *resource.Resource {
+	metadata := map[string]interface{}{"name": p.Name}
+	if ns != resid.DefaultNamespace {
+		metadata["namespace"] = ns
+	}
+	spec := map[string]interface{}{
+		"podSelector": map[string]interface{}{},
+		"policyTypes": []interface{}{"Ingress", "Egress"},
+	}
+	if p.AllowDNS {
+		spec["egress"] = []interface{}{
+			map[string]interface{}{
+				"ports": []interface{}{
+					map[string]interface{}{"port": 53, "protocol": "UDP"},
+					map[string]interface{}{"port": 53, "protocol": "TCP"},
+				},
+			},
+		}
+	}
+	return p.h.ResmapFactory().RF().FromMap(map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "NetworkPolicy",
+		"metadata":   metadata,
+		"spec":       spec,
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/envtofile"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Move literal env vars of workloads into a file of KEY=VALUE
+// lines, held by a ConfigMap named after the workload (<name>-env)
+// and mounted into the containers that used them. If the ConfigMap
+// exists, the env vars are merged into its file.
+type plugin struct {
+	h         *resmap.PluginHelpers
+	EnvVars   []string        `json:"envVars,omitempty" yaml:"envVars,omitempty"`
+	FileName  string          `json:"fileName,omitempty" yaml:"fileName,omitempty"`
+	MountPath string          `json:"mountPath,omitempty" yaml:"mountPath,omitempty"`
+	Target    *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	h *resmap.PluginHelpers, c []byte) (err error) {
+	p.h = h
+	p.EnvVars = nil
+	p.FileName = ""
+	p.MountPath = ""
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.EnvVars) == 0 {
+		return fmt.Errorf("must specify at least one env var")
+	}
+	if p.FileName == "" || p.MountPath == "" {
+		return fmt.Errorf("must specify fileName and mountPath")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		if !r.IsWorkload() {
+			continue
+		}
+		cmName := r.GetName() + "-env"
+		env := make(map[string]string)
+		err = r.ApplyFilter(envtofile.Filter{
+			EnvVars:       p.EnvVars,
+			ConfigMapName: cmName,
+			MountPath:     p.MountPath,
+			Env:           env,
+		})
+		if err != nil {
+			return err
+		}
+		if len(env) == 0 {
+			continue
+		}
+		metadata := map[string]interface{}{"name": cmName}
+		if ns := r.GetNamespace(); ns != "" {
+			metadata["namespace"] = ns
+		}
+		cm := p.h.ResmapFactory().RF().FromMap(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   metadata,
+		})
+		// The ConfigMap exists already, e.g. if a base ran the
+		// transformer too; the env vars are merged into its file.
+		if matches := m.GetMatchingResourcesByCurrentId(cm.CurId().Equals); len(matches) > 0 {
+			cm = matches[0]
+		} else if err = m.Append(cm); err != nil {
+			return err
+		}
+		data := cm.GetDataMap()
+		data[p.FileName] = p.mergeEnvFile(data[p.FileName], env)
+		cm.SetDataMap(data)
+	}
+	return nil
+}
+
+// mergeEnvFile returns the KEY=VALUE lines of content, with the
+// values of env replacing those of the same keys, followed by the
+// lines of the other keys of env in the order of p.EnvVars.
+func (p *plugin) mergeEnvFile(content string, env map[string]string) string {
+	var result strings.Builder
+	written := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		if line == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(line, "=")
+		if v, ok := env[name]; ok {
+			line = name + "=" + v
+			written[name] = true
+		}
+		result.WriteString(line + "\n")
+	}
+	for _, name := range p.EnvVars {
+		if v, ok := env[name]; ok && !written[name] {
+			result.WriteString(name + "=" + v + "\n")
+		}
+	}
+	return result.String()
+}
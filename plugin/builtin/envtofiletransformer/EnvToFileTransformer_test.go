@@ -0,0 +1,183 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestEnvToFileTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("EnvToFileTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: EnvToFileTransformer
+metadata:
+  name: notImportantHere
+envVars:
+- DB_HOST
+- DB_PORT
+fileName: db.env
+mountPath: /etc/app
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: prod
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        env:
+        - name: DB_PORT
+          value: "5432"
+        - name: LOG_LEVEL
+          value: info
+        - name: DB_HOST
+          value: db
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: prod
+spec:
+  template:
+    spec:
+      containers:
+      - env:
+        - name: LOG_LEVEL
+          value: info
+        image: app
+        name: app
+        volumeMounts:
+        - mountPath: /etc/app
+          name: app-env
+      volumes:
+      - configMap:
+          name: app-env
+        name: app-env
+---
+apiVersion: v1
+data:
+  db.env: |
+    DB_HOST=db
+    DB_PORT=5432
+kind: ConfigMap
+metadata:
+  name: app-env
+  namespace: prod
+`)
+}
+
+func TestEnvToFileTransformerExistingConfigMap(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("EnvToFileTransformer")
+	defer th.Reset()
+
+	// E.g. a base that ran the transformer already.
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: EnvToFileTransformer
+metadata:
+  name: notImportantHere
+envVars:
+- DB_HOST
+- DB_PORT
+fileName: db.env
+mountPath: /etc/app
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        env:
+        - name: DB_PORT
+          value: "5432"
+        - name: DB_HOST
+          value: db
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-env
+data:
+  db.env: |
+    DB_HOST=old
+    DB_USER=app
+  other.env: |
+    LOG_LEVEL=info
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: app
+        name: app
+        volumeMounts:
+        - mountPath: /etc/app
+          name: app-env
+      volumes:
+      - configMap:
+          name: app-env
+        name: app-env
+---
+apiVersion: v1
+data:
+  db.env: |
+    DB_HOST=db
+    DB_USER=app
+    DB_PORT=5432
+  other.env: |
+    LOG_LEVEL=info
+kind: ConfigMap
+metadata:
+  name: app-env
+`)
+}
+
+func TestEnvToFileTransformerMissingMountPath(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("EnvToFileTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: EnvToFileTransformer
+metadata:
+  name: notImportantHere
+envVars:
+- DB_HOST
+fileName: db.env
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "must specify fileName and mountPath") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/externalname"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Rewrite the domain of the externalName of ExternalName Services,
+// e.g. from svc.staging.example.com to svc.prod.example.com.
+type plugin struct {
+	Domains map[string]string `json:"domains,omitempty" yaml:"domains,omitempty"`
+	Target  *types.Selector   `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Domains = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.Domains) == 0 {
+		return fmt.Errorf("must specify domains")
+	}
+	for from, to := range p.Domains {
+		if from == "" || to == "" {
+			return fmt.Errorf("domains must not be empty")
+		}
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		if err = r.ApplyFilter(externalname.Filter{Domains: p.Domains}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
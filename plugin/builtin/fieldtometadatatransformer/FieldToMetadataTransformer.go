@@ -0,0 +1,64 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/fieldtometadata"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Copy the value of a field of resources, e.g. spec.replicas,
+// into a label or an annotation.
+type plugin struct {
+	FieldPath  string          `json:"fieldPath,omitempty" yaml:"fieldPath,omitempty"`
+	Label      string          `json:"label,omitempty" yaml:"label,omitempty"`
+	Annotation string          `json:"annotation,omitempty" yaml:"annotation,omitempty"`
+	Target     *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.FieldPath = ""
+	p.Label = ""
+	p.Annotation = ""
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.FieldPath == "" {
+		return fmt.Errorf("must specify fieldPath")
+	}
+	if p.Label == "" && p.Annotation == "" {
+		return fmt.Errorf("must specify at least one of label and annotation")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(fieldtometadata.Filter{
+			FieldPath:  p.FieldPath,
+			Label:      p.Label,
+			Annotation: p.Annotation,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
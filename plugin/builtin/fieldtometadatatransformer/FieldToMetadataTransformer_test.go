@@ -0,0 +1,78 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestFieldToMetadataTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("FieldToMetadataTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: FieldToMetadataTransformer
+metadata:
+  name: notImportantHere
+fieldPath: spec.replicas
+annotation: example.com/replicas
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 3
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    example.com/replicas: "3"
+  name: app
+spec:
+  replicas: 3
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`)
+}
+
+func TestFieldToMetadataTransformerNoKey(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("FieldToMetadataTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: FieldToMetadataTransformer
+metadata:
+  name: notImportantHere
+fieldPath: spec.replicas
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		"must specify at least one of label and annotation") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/fsgroup"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Set the fsGroup and supplementalGroups of the pod security
+// context of workloads, so that mounted volumes are writable
+// by the pod's processes.
+type plugin struct {
+	FsGroup            *int64          `json:"fsGroup,omitempty" yaml:"fsGroup,omitempty"`
+	SupplementalGroups []int64         `json:"supplementalGroups,omitempty" yaml:"supplementalGroups,omitempty"`
+	Overwrite          bool            `json:"overwrite,omitempty" yaml:"overwrite,omitempty"`
+	Target             *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.FsGroup = nil
+	p.SupplementalGroups = nil
+	p.Overwrite = false
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.FsGroup == nil && len(p.SupplementalGroups) == 0 {
+		return fmt.Errorf(
+			"must specify at least one of fsGroup and supplementalGroups")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(fsgroup.Filter{
+			FsGroup:            p.FsGroup,
+			SupplementalGroups: p.SupplementalGroups,
+			Overwrite:          p.Overwrite,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+const workloads = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bare
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: explicit
+spec:
+  template:
+    spec:
+      securityContext:
+        fsGroup: 1000
+      containers:
+      - name: app
+        image: nginx
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`
+
+func TestFsGroupTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("FsGroupTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: FsGroupTransformer
+metadata:
+  name: notImportantHere
+fsGroup: 2000
+supplementalGroups:
+- 3000
+`, workloads, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bare
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx
+        name: app
+      securityContext:
+        fsGroup: 2000
+        supplementalGroups:
+        - 3000
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: explicit
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx
+        name: app
+      securityContext:
+        fsGroup: 1000
+        supplementalGroups:
+        - 3000
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+}
+
+func TestFsGroupTransformerOverwriteWithTarget(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("FsGroupTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: FsGroupTransformer
+metadata:
+  name: notImportantHere
+fsGroup: 2000
+overwrite: true
+target:
+  name: explicit
+`, workloads, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bare
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx
+        name: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: explicit
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx
+        name: app
+      securityContext:
+        fsGroup: 2000
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+}
+
+func TestFsGroupTransformerNoGroups(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("FsGroupTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: FsGroupTransformer
+metadata:
+  name: notImportantHere
+`, workloads)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		"must specify at least one of fsGroup and supplementalGroups") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"fmt"
+	"regexp"
 
 	"sigs.k8s.io/kustomize/api/ifc"
 	"sigs.k8s.io/kustomize/api/resmap"
@@ -15,6 +16,9 @@ type plugin struct {
 	hasher ifc.KustHasher
 }
 
+// dnsLabel matches the hashes that can be appended to names.
+var dnsLabel = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`) //nolint:gochecknoglobals
+
 var KustomizePlugin plugin //nolint:gochecknoglobals
 
 func (p *plugin) Config(
@@ -31,6 +35,11 @@ func (p *plugin) Transform(m resmap.ResMap) error {
 			if err != nil {
 				return err
 			}
+			if !dnsLabel.MatchString(h) {
+				return fmt.Errorf(
+					"hash %q of %s is not a valid name suffix; it must be a DNS label",
+					h, res.CurId())
+			}
 			res.StorePreviousId()
 			res.SetName(fmt.Sprintf("%s-%s", res.GetName(), h))
 		}
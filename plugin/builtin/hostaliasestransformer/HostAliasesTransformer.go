@@ -0,0 +1,58 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/hostaliases"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Add host aliases to the pod specs of workloads, merging
+// hostnames into existing aliases with the same IP.
+type plugin struct {
+	HostAliases []hostaliases.HostAlias `json:"hostAliases,omitempty" yaml:"hostAliases,omitempty"`
+	Target      *types.Selector         `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.HostAliases = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.HostAliases) == 0 {
+		return fmt.Errorf("must specify at least one host alias")
+	}
+	for _, ha := range p.HostAliases {
+		if ha.IP == "" || len(ha.Hostnames) == 0 {
+			return fmt.Errorf("host alias must have an ip and hostnames")
+		}
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(hostaliases.Filter{HostAliases: p.HostAliases})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestHostAliasesTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("HostAliasesTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: HostAliasesTransformer
+metadata:
+  name: notImportantHere
+hostAliases:
+- ip: 10.0.0.5
+  hostnames:
+  - db
+  - db.legacy
+- ip: 10.0.0.6
+  hostnames:
+  - cache
+target:
+  name: legacy
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: legacy
+spec:
+  template:
+    spec:
+      hostAliases:
+      - ip: 10.0.0.5
+        hostnames:
+        - db
+      containers:
+      - name: app
+        image: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: modern
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: legacy
+spec:
+  template:
+    spec:
+      containers:
+      - image: app
+        name: app
+      hostAliases:
+      - hostnames:
+        - db
+        - db.legacy
+        ip: 10.0.0.5
+      - hostnames:
+        - cache
+        ip: 10.0.0.6
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: modern
+spec:
+  template:
+    spec:
+      containers:
+      - image: app
+        name: app
+`)
+}
+
+func TestHostAliasesTransformerMissingIP(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("HostAliasesTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: HostAliasesTransformer
+metadata:
+  name: notImportantHere
+hostAliases:
+- hostnames:
+  - db
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "host alias must have an ip and hostnames") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/imagedigests"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Pin the images of the containers of workloads to the digests
+// listed in a lockfile, which maps images to digests, e.g.
+//
+//	nginx:1.25: sha256:...
+//	registry.example/app: sha256:...
+type plugin struct {
+	LockFile string          `json:"lockFile,omitempty" yaml:"lockFile,omitempty"`
+	Strict   bool            `json:"strict,omitempty" yaml:"strict,omitempty"`
+	Target   *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+
+	digests map[string]string
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	h *resmap.PluginHelpers, c []byte) (err error) {
+	p.LockFile = ""
+	p.Strict = false
+	p.Target = nil
+	p.digests = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.LockFile == "" {
+		return fmt.Errorf("must specify a lockFile")
+	}
+	content, err := h.Loader().Load(p.LockFile)
+	if err != nil {
+		return fmt.Errorf("unable to load lockFile %s: %w", p.LockFile, err)
+	}
+	if err = yaml.Unmarshal(content, &p.digests); err != nil {
+		return fmt.Errorf("unable to parse lockFile %s: %w", p.LockFile, err)
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(imagedigests.Filter{
+			Digests: p.digests,
+			Strict:  p.Strict,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
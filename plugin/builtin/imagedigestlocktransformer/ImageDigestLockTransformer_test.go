@@ -0,0 +1,93 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+const lockFile = `
+nginx:1.25: sha256:aaaa
+registry.example/app: sha256:bbbb
+`
+
+func TestImageDigestLockTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ImageDigestLockTransformer")
+	defer th.Reset()
+
+	th.WriteF("images.lock", lockFile)
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: ImageDigestLockTransformer
+metadata:
+  name: notImportantHere
+lockFile: images.lock
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25
+      - name: app
+        image: registry.example/app:v2
+      - name: debug
+        image: busybox
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: nginx@sha256:aaaa
+        name: web
+      - image: registry.example/app@sha256:bbbb
+        name: app
+      - image: busybox
+        name: debug
+`)
+}
+
+func TestImageDigestLockTransformerStrict(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ImageDigestLockTransformer")
+	defer th.Reset()
+
+	th.WriteF("images.lock", lockFile)
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: ImageDigestLockTransformer
+metadata:
+  name: notImportantHere
+lockFile: images.lock
+strict: true
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		`image "busybox" of Pod debug is not in the digest lockfile`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
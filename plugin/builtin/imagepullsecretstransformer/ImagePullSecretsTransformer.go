@@ -0,0 +1,59 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/imagepullsecrets"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Add imagePullSecrets to workloads, and optionally to
+// ServiceAccounts, so that images can be pulled from
+// private registries.
+type plugin struct {
+	Secrets         []string        `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	ServiceAccounts bool            `json:"serviceAccounts,omitempty" yaml:"serviceAccounts,omitempty"`
+	Target          *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Secrets = nil
+	p.ServiceAccounts = false
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.Secrets) == 0 {
+		return fmt.Errorf("must specify at least one secret")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(imagepullsecrets.Filter{
+			Secrets:         p.Secrets,
+			ServiceAccounts: p.ServiceAccounts,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+const config = `
+apiVersion: builtin
+kind: ImagePullSecretsTransformer
+metadata:
+  name: notImportantHere
+secrets:
+- regcred
+serviceAccounts: true
+`
+
+const expected = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: registry.example.com/app
+        name: app
+      imagePullSecrets:
+      - name: regcred
+---
+apiVersion: v1
+imagePullSecrets:
+- name: regcred
+kind: ServiceAccount
+metadata:
+  name: default
+`
+
+func TestImagePullSecretsTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ImagePullSecretsTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(config, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: registry.example.com/app
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: default
+`, expected)
+}
+
+func TestImagePullSecretsTransformerRerun(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ImagePullSecretsTransformer")
+	defer th.Reset()
+
+	// Running again on its own output changes nothing.
+	th.RunTransformerAndCheckResult(config, expected, expected)
+}
+
+func TestImagePullSecretsTransformerNoSecrets(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ImagePullSecretsTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: ImagePullSecretsTransformer
+metadata:
+  name: notImportantHere
+`, expected)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "must specify at least one secret") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestLabelRemovalTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("LabelRemovalTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: LabelRemovalTransformer
+metadata:
+  name: notImportantHere
+labels:
+- legacy-id
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app: web
+    legacy-id: "42"
+spec:
+  selector:
+    matchLabels:
+      app: web
+      legacy-id: "42"
+  template:
+    metadata:
+      labels:
+        app: web
+        legacy-id: "42"
+    spec:
+      containers:
+      - name: web
+        image: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    app: web
+    legacy-id: "42"
+spec:
+  selector:
+    app: web
+    legacy-id: "42"
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: web
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+      legacy-id: "42"
+  template:
+    metadata:
+      labels:
+        app: web
+        legacy-id: "42"
+    spec:
+      containers:
+      - image: web
+        name: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    app: web
+  name: web
+spec:
+  selector:
+    app: web
+    legacy-id: "42"
+`)
+}
+
+func TestLabelRemovalTransformerTemplate(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("LabelRemovalTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: LabelRemovalTransformer
+metadata:
+  name: notImportantHere
+labels:
+- owner
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    owner: team-a
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+        owner: team-a
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+`)
+}
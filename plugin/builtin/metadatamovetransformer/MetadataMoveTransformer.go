@@ -0,0 +1,59 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/metadatamove"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Move values from annotations to labels, and from labels to
+// annotations, renaming their keys.
+type plugin struct {
+	AnnotationsToLabels map[string]string `json:"annotationsToLabels,omitempty" yaml:"annotationsToLabels,omitempty"`
+	LabelsToAnnotations map[string]string `json:"labelsToAnnotations,omitempty" yaml:"labelsToAnnotations,omitempty"`
+	Target              *types.Selector   `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.AnnotationsToLabels = nil
+	p.LabelsToAnnotations = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.AnnotationsToLabels) == 0 && len(p.LabelsToAnnotations) == 0 {
+		return fmt.Errorf(
+			"must specify annotationsToLabels or labelsToAnnotations")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(metadatamove.Filter{
+			AnnotationsToLabels: p.AnnotationsToLabels,
+			LabelsToAnnotations: p.LabelsToAnnotations,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestMetadataMoveTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("MetadataMoveTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: MetadataMoveTransformer
+metadata:
+  name: notImportantHere
+annotationsToLabels:
+  example.com/team: team
+labelsToAnnotations:
+  commit: example.com/commit
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  annotations:
+    example.com/team: payments
+  labels:
+    app: web
+    commit: 0a1b2c3
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    example.com/commit: 0a1b2c3
+  labels:
+    app: web
+    team: payments
+  name: web
+`)
+}
+
+func TestMetadataMoveTransformerInvalidLabelValue(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("MetadataMoveTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: MetadataMoveTransformer
+metadata:
+  name: notImportantHere
+annotationsToLabels:
+  example.com/owner: owner
+`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  annotations:
+    example.com/owner: jane@example.com
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), `invalid label value "jane@example.com"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/prestop"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Add a preStop lifecycle hook, e.g. a sleep that lets load
+// balancers drain connections, to the containers of workloads
+// that don't have one.
+type plugin struct {
+	PreStop    *prestop.Handler `json:"preStop,omitempty" yaml:"preStop,omitempty"`
+	Containers []string         `json:"containers,omitempty" yaml:"containers,omitempty"`
+	Target     *types.Selector  `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.PreStop = nil
+	p.Containers = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.PreStop == nil {
+		return fmt.Errorf("must specify a preStop hook")
+	}
+	if err = p.PreStop.Validate(); err != nil {
+		return fmt.Errorf("invalid preStop hook: %w", err)
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(prestop.Filter{
+			PreStop:    p.PreStop,
+			Containers: p.Containers,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestPreStopHookTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("PreStopHookTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: PreStopHookTransformer
+metadata:
+  name: notImportantHere
+preStop:
+  exec:
+    command: ["sleep", "15"]
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        lifecycle:
+          postStart:
+            exec:
+              command: ["warm-up"]
+      - name: proxy
+        image: proxy
+        lifecycle:
+          preStop:
+            httpGet:
+              path: /drain
+              port: 15000
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: app
+        lifecycle:
+          postStart:
+            exec:
+              command:
+              - warm-up
+          preStop:
+            exec:
+              command:
+              - sleep
+              - "15"
+        name: app
+      - image: proxy
+        lifecycle:
+          preStop:
+            httpGet:
+              path: /drain
+              port: 15000
+        name: proxy
+`)
+}
+
+func TestPreStopHookTransformerInvalidHook(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("PreStopHookTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: PreStopHookTransformer
+metadata:
+  name: notImportantHere
+preStop:
+  httpGet:
+    path: /drain
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		"invalid preStop hook: httpGet handler must have a port") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/probedefaults"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Add default liveness and readiness probes to the containers
+// of workloads that don't have them.
+type plugin struct {
+	Liveness   *probedefaults.Probe `json:"liveness,omitempty" yaml:"liveness,omitempty"`
+	Readiness  *probedefaults.Probe `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+	Containers []string             `json:"containers,omitempty" yaml:"containers,omitempty"`
+	Target     *types.Selector      `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Liveness = nil
+	p.Readiness = nil
+	p.Containers = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.Liveness == nil && p.Readiness == nil {
+		return fmt.Errorf("must specify a liveness or readiness probe")
+	}
+	if p.Liveness != nil {
+		if err = p.Liveness.Validate(); err != nil {
+			return fmt.Errorf("invalid liveness probe: %w", err)
+		}
+	}
+	if p.Readiness != nil {
+		if err = p.Readiness.Validate(); err != nil {
+			return fmt.Errorf("invalid readiness probe: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(probedefaults.Filter{
+			Liveness:   p.Liveness,
+			Readiness:  p.Readiness,
+			Containers: p.Containers,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestProbeDefaultsTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ProbeDefaultsTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: ProbeDefaultsTransformer
+metadata:
+  name: notImportantHere
+liveness:
+  httpGet:
+    path: /healthz
+    port: 8080
+readiness:
+  tcpSocket:
+    port: 8080
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+      - name: proxy
+        image: proxy
+        readinessProbe:
+          httpGet:
+            path: /ready
+            port: 15021
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - image: app
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8080
+        name: app
+        readinessProbe:
+          tcpSocket:
+            port: 8080
+      - image: proxy
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8080
+        name: proxy
+        readinessProbe:
+          httpGet:
+            path: /ready
+            port: 15021
+`)
+}
+
+func TestProbeDefaultsTransformerInvalidProbe(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ProbeDefaultsTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: ProbeDefaultsTransformer
+metadata:
+  name: notImportantHere
+liveness:
+  httpGet:
+    path: /healthz
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		"invalid liveness probe: httpGet probe must have a port") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
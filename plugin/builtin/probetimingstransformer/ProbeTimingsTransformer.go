@@ -0,0 +1,65 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/probetimings"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Set the timeoutSeconds, periodSeconds and failureThreshold of
+// the probes of workload containers that don't set them.
+type plugin struct {
+	TimeoutSeconds   int             `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"`
+	PeriodSeconds    int             `json:"periodSeconds,omitempty" yaml:"periodSeconds,omitempty"`
+	FailureThreshold int             `json:"failureThreshold,omitempty" yaml:"failureThreshold,omitempty"`
+	Target           *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.TimeoutSeconds = 0
+	p.PeriodSeconds = 0
+	p.FailureThreshold = 0
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.TimeoutSeconds < 0 || p.PeriodSeconds < 0 || p.FailureThreshold < 0 {
+		return fmt.Errorf("probe timings must not be negative")
+	}
+	if p.TimeoutSeconds == 0 && p.PeriodSeconds == 0 && p.FailureThreshold == 0 {
+		return fmt.Errorf(
+			"must specify timeoutSeconds, periodSeconds or failureThreshold")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(probetimings.Filter{
+			TimeoutSeconds:   p.TimeoutSeconds,
+			PeriodSeconds:    p.PeriodSeconds,
+			FailureThreshold: p.FailureThreshold,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"sigs.k8s.io/kustomize/api/filters/readonlyrootfs"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Make the root filesystem of the containers of workloads
+// read-only, except for the containers that need to write to it.
+type plugin struct {
+	Exempt      []string        `json:"exempt,omitempty" yaml:"exempt,omitempty"`
+	WritableTmp bool            `json:"writableTmp,omitempty" yaml:"writableTmp,omitempty"`
+	Target      *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Exempt = nil
+	p.WritableTmp = false
+	p.Target = nil
+	return yaml.Unmarshal(c, p)
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(readonlyrootfs.Filter{
+			Exempt:      p.Exempt,
+			WritableTmp: p.WritableTmp,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
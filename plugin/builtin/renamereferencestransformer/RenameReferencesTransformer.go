@@ -0,0 +1,58 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/renamerefs"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Rewrite the references that workloads make to ConfigMaps and
+// Secrets that were renamed outside of kustomize's generators.
+type plugin struct {
+	Renames []renamerefs.Rename `json:"renames,omitempty" yaml:"renames,omitempty"`
+	Target  *types.Selector     `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Renames = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.Renames) == 0 {
+		return fmt.Errorf("must specify at least one rename")
+	}
+	for _, r := range p.Renames {
+		if err = r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(renamerefs.Filter{Renames: p.Renames})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestRenameReferencesTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("RenameReferencesTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: RenameReferencesTransformer
+metadata:
+  name: notImportantHere
+renames:
+- kind: ConfigMap
+  from: settings
+  to: settings-v2
+- kind: Secret
+  from: creds
+  to: creds-v2
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        envFrom:
+        - configMapRef:
+            name: settings
+        env:
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: creds
+              key: password
+      volumes:
+      - name: config
+        configMap:
+          name: settings
+      - name: all
+        projected:
+          sources:
+          - secret:
+              name: creds
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - env:
+        - name: PASSWORD
+          valueFrom:
+            secretKeyRef:
+              key: password
+              name: creds-v2
+        envFrom:
+        - configMapRef:
+            name: settings-v2
+        image: app
+        name: app
+      volumes:
+      - configMap:
+          name: settings-v2
+        name: config
+      - name: all
+        projected:
+          sources:
+          - secret:
+              name: creds-v2
+`)
+}
+
+func TestRenameReferencesTransformerBadKind(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("RenameReferencesTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: RenameReferencesTransformer
+metadata:
+  name: notImportantHere
+renames:
+- kind: Service
+  from: a
+  to: b
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "must be ConfigMap or Secret") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
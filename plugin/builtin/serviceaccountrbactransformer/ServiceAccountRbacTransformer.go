@@ -0,0 +1,143 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/serviceaccount"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	"sigs.k8s.io/yaml"
+)
+
+const rbacGroup = "rbac.authorization.k8s.io"
+
+// PolicyRule is a rule of the generated Role or ClusterRole.
+type PolicyRule struct {
+	APIGroups     []string `json:"apiGroups,omitempty" yaml:"apiGroups,omitempty"`
+	Resources     []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	ResourceNames []string `json:"resourceNames,omitempty" yaml:"resourceNames,omitempty"`
+	Verbs         []string `json:"verbs,omitempty" yaml:"verbs,omitempty"`
+}
+
+// Add a ServiceAccount, a Role and a RoleBinding granting the
+// rules to the ServiceAccount, all with the same name, and make
+// the selected workloads run as the ServiceAccount. With clusterWide,
+// a ClusterRole and a ClusterRoleBinding are added instead. Objects
+// that already exist are not added again.
+type plugin struct {
+	h           *resmap.PluginHelpers
+	Name        string          `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace   string          `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	ClusterWide bool            `json:"clusterWide,omitempty" yaml:"clusterWide,omitempty"`
+	Rules       []PolicyRule    `json:"rules,omitempty" yaml:"rules,omitempty"`
+	Target      *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	h *resmap.PluginHelpers, c []byte) (err error) {
+	p.h = h
+	p.Name = ""
+	p.Namespace = ""
+	p.ClusterWide = false
+	p.Rules = nil
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.Name == "" {
+		return fmt.Errorf("must specify a name")
+	}
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("must specify at least one rule")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) error {
+	workloads, err := p.workloads(m)
+	if err != nil {
+		return err
+	}
+	roleKind, bindingKind := "Role", "RoleBinding"
+	if p.ClusterWide {
+		roleKind, bindingKind = "ClusterRole", "ClusterRoleBinding"
+	}
+	for _, r := range []*resource.Resource{
+		p.object("v1", "ServiceAccount", true, nil),
+		p.object(rbacGroup+"/v1", roleKind, !p.ClusterWide,
+			map[string]interface{}{"rules": p.Rules}),
+		p.object(rbacGroup+"/v1", bindingKind, !p.ClusterWide,
+			map[string]interface{}{
+				"roleRef": map[string]interface{}{
+					"apiGroup": rbacGroup,
+					"kind":     roleKind,
+					"name":     p.Name,
+				},
+				"subjects": []interface{}{
+					map[string]interface{}{
+						"kind":      "ServiceAccount",
+						"name":      p.Name,
+						"namespace": p.namespace(),
+					},
+				},
+			}),
+	} {
+		if len(m.GetMatchingResourcesByCurrentId(r.CurId().Equals)) > 0 {
+			continue
+		}
+		if err = m.Append(r); err != nil {
+			return err
+		}
+	}
+	for _, r := range workloads {
+		err = r.ApplyFilter(serviceaccount.Filter{Name: p.Name})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// workloads returns the resources selected by the target, or else
+// the resources in the namespace of the ServiceAccount.
+func (p *plugin) workloads(m resmap.ResMap) ([]*resource.Resource, error) {
+	if p.Target != nil {
+		return m.Select(*p.Target)
+	}
+	return m.Select(types.Selector{ResId: resid.ResId{Namespace: p.namespace()}})
+}
+
+func (p *plugin) namespace() string {
+	if p.Namespace == "" {
+		return resid.DefaultNamespace
+	}
+	return p.Namespace
+}
+
+// object returns a new object of the given kind named after the
+// ServiceAccount, with the given top-level fields.
+func (p *plugin) object(
+	apiVersion, kind string, namespaced bool,
+	fields map[string]interface{}) *resource.Resource {
+	metadata := map[string]interface{}{"name": p.Name}
+	if namespaced && p.Namespace != "" {
+		metadata["namespace"] = p.Namespace
+	}
+	obj := map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+	}
+	for k, v := range fields {
+		obj[k] = v
+	}
+	return p.h.ResmapFactory().RF().FromMap(obj)
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestServiceAccountRbacTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ServiceAccountRbacTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: ServiceAccountRbacTransformer
+metadata:
+  name: notImportantHere
+name: reader
+namespace: shop
+rules:
+- apiGroups: [""]
+  resources: [configmaps]
+  verbs: [get, list]
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: other
+  namespace: backend
+spec:
+  template:
+    spec:
+      containers:
+      - name: other
+        image: other
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: reader
+  namespace: shop
+  labels:
+    existing: "true"
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  template:
+    spec:
+      containers:
+      - image: web
+        name: web
+      serviceAccountName: reader
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: other
+  namespace: backend
+spec:
+  template:
+    spec:
+      containers:
+      - image: other
+        name: other
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  labels:
+    existing: "true"
+  name: reader
+  namespace: shop
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: reader
+  namespace: shop
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - configmaps
+  verbs:
+  - get
+  - list
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: reader
+  namespace: shop
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: reader
+subjects:
+- kind: ServiceAccount
+  name: reader
+  namespace: shop
+`)
+}
+
+func TestServiceAccountRbacTransformerClusterWide(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ServiceAccountRbacTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: ServiceAccountRbacTransformer
+metadata:
+  name: notImportantHere
+name: watcher
+clusterWide: true
+rules:
+- apiGroups: [""]
+  resources: [nodes]
+  verbs: [watch]
+target:
+  kind: Deployment
+  name: web
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      serviceAccountName: default
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      serviceAccountName: watcher
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: watcher
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: watcher
+rules:
+- apiGroups:
+  - ""
+  resources:
+  - nodes
+  verbs:
+  - watch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: watcher
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: watcher
+subjects:
+- kind: ServiceAccount
+  name: watcher
+  namespace: default
+`)
+}
+
+func TestServiceAccountRbacTransformerNoRules(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("ServiceAccountRbacTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: ServiceAccountRbacTransformer
+metadata:
+  name: notImportantHere
+name: reader
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "must specify at least one rule") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
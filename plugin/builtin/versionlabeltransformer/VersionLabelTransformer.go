@@ -0,0 +1,91 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/filtersutil"
+	"sigs.k8s.io/kustomize/api/filters/labels"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+const versionLabel = "app.kubernetes.io/version"
+
+// Stamp the version and revision of a build, as labels, onto
+// resources and the pod templates of workloads.
+// Selectors are left alone, since they are immutable once applied.
+type plugin struct {
+	Version       string          `json:"version,omitempty" yaml:"version,omitempty"`
+	Revision      string          `json:"revision,omitempty" yaml:"revision,omitempty"`
+	RevisionLabel string          `json:"revisionLabel,omitempty" yaml:"revisionLabel,omitempty"`
+	Target        *types.Selector `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Version = ""
+	p.Revision = ""
+	p.RevisionLabel = ""
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if p.Version == "" && p.Revision == "" {
+		return fmt.Errorf("must specify at least one of version and revision")
+	}
+	if p.Revision != "" && p.RevisionLabel == "" {
+		return fmt.Errorf("must specify revisionLabel to set a revision")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	l := make(map[string]string)
+	if p.Version != "" {
+		l[versionLabel] = p.Version
+	}
+	if p.Revision != "" {
+		l[p.RevisionLabel] = p.Revision
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(labels.Filter{
+			Labels:  l,
+			FsSlice: labelFieldSpecs(r.GetKind()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelFieldSpecs returns the label fields to set on objects of
+// the given kind: their own labels, and the labels of their pod
+// template if they have one.
+func labelFieldSpecs(kind string) types.FsSlice {
+	fsSlice := types.FsSlice{{Path: "metadata/labels", CreateIfNotPresent: true}}
+	podSpec := filtersutil.PodSpecPath(kind)
+	if len(podSpec) > 1 {
+		template := append(podSpec[:len(podSpec)-1], "metadata", "labels")
+		fsSlice = append(fsSlice, types.FieldSpec{
+			Path:               strings.Join(template, "/"),
+			CreateIfNotPresent: true,
+		})
+	}
+	return fsSlice
+}
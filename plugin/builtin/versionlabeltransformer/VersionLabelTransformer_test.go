@@ -0,0 +1,156 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+func TestVersionLabelTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("VersionLabelTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: VersionLabelTransformer
+metadata:
+  name: notImportantHere
+version: 1.4.2
+revision: 3f2c1ab
+revisionLabel: example.com/revision
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: shop
+spec:
+  selector:
+    matchLabels:
+      app: shop
+  template:
+    metadata:
+      labels:
+        app: shop
+    spec:
+      containers:
+      - name: app
+        image: shop
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app: shop
+    app.kubernetes.io/version: 1.4.2
+    example.com/revision: 3f2c1ab
+  name: app
+spec:
+  selector:
+    matchLabels:
+      app: shop
+  template:
+    metadata:
+      labels:
+        app: shop
+        app.kubernetes.io/version: 1.4.2
+        example.com/revision: 3f2c1ab
+    spec:
+      containers:
+      - image: shop
+        name: app
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  labels:
+    app.kubernetes.io/version: 1.4.2
+    example.com/revision: 3f2c1ab
+  name: cm
+`)
+}
+
+func TestVersionLabelTransformerCronJobWithTarget(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("VersionLabelTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: VersionLabelTransformer
+metadata:
+  name: notImportantHere
+version: "2.0"
+target:
+  kind: CronJob
+`, `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "@daily"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`, `
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  labels:
+    app.kubernetes.io/version: "2.0"
+  name: backup
+spec:
+  jobTemplate:
+    spec:
+      template:
+        metadata:
+          labels:
+            app.kubernetes.io/version: "2.0"
+  schedule: '@daily'
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+}
+
+func TestVersionLabelTransformerMissingRevisionLabel(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("VersionLabelTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: VersionLabelTransformer
+metadata:
+  name: notImportantHere
+revision: 3f2c1ab
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+`)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		"must specify revisionLabel to set a revision") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
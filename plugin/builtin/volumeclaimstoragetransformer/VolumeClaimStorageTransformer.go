@@ -0,0 +1,59 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate pluginator
+package main
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/filters/claimstorage"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Rewrite the storage requested by the volumeClaimTemplates of
+// StatefulSets, either to the size given for the claim template
+// or by scaling it with a multiplier.
+type plugin struct {
+	Sizes      map[string]string `json:"sizes,omitempty" yaml:"sizes,omitempty"`
+	Multiplier string            `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+	Target     *types.Selector   `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+var KustomizePlugin plugin //nolint:gochecknoglobals
+
+func (p *plugin) Config(
+	_ *resmap.PluginHelpers, c []byte) (err error) {
+	p.Sizes = nil
+	p.Multiplier = ""
+	p.Target = nil
+	if err = yaml.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if len(p.Sizes) == 0 && p.Multiplier == "" {
+		return fmt.Errorf("must specify sizes or a multiplier")
+	}
+	return nil
+}
+
+func (p *plugin) Transform(m resmap.ResMap) (err error) {
+	resources := m.Resources()
+	if p.Target != nil {
+		resources, err = m.Select(*p.Target)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range resources {
+		err = r.ApplyFilter(claimstorage.Filter{
+			Sizes:      p.Sizes,
+			Multiplier: p.Multiplier,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"strings"
+	"testing"
+
+	kusttest_test "sigs.k8s.io/kustomize/api/testutils/kusttest"
+)
+
+const statefulSet = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      resources:
+        requests:
+          storage: 10Gi
+`
+
+func TestVolumeClaimStorageTransformer(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("VolumeClaimStorageTransformer")
+	defer th.Reset()
+
+	th.RunTransformerAndCheckResult(`
+apiVersion: builtin
+kind: VolumeClaimStorageTransformer
+metadata:
+  name: notImportantHere
+multiplier: "2"
+`, statefulSet, `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      resources:
+        requests:
+          storage: 20Gi
+`)
+}
+
+func TestVolumeClaimStorageTransformerInvalidResult(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("VolumeClaimStorageTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: VolumeClaimStorageTransformer
+metadata:
+  name: notImportantHere
+sizes:
+  data: -5Gi
+`, statefulSet)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(),
+		`claim template "data" of StatefulSet db: invalid storage size "-5Gi"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVolumeClaimStorageTransformerNoConfig(t *testing.T) {
+	th := kusttest_test.MakeEnhancedHarness(t).
+		PrepBuiltin("VolumeClaimStorageTransformer")
+	defer th.Reset()
+
+	err := th.ErrorFromLoadAndRunTransformer(`
+apiVersion: builtin
+kind: VolumeClaimStorageTransformer
+metadata:
+  name: notImportantHere
+`, statefulSet)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !strings.Contains(err.Error(), "must specify sizes or a multiplier") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}